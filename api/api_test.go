@@ -0,0 +1,324 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+// ******** Private constants ********
+
+// testContextId is the context id used for the tests.
+const testContextId = `api test`
+
+// ******** Test functions ********
+
+func TestSignVerify(t *testing.T) {
+	makeTestFiles(t)
+
+	result := signTestFiles(t)
+	if len(result.FilePaths) != 2 {
+		t.Fatalf(`%d files signed instead of 2`, len(result.FilePaths))
+	}
+
+	report := verifyTestFiles(t, result.VerificationId)
+	if !report.IsOk() {
+		t.Fatalf(`Verification not ok: %v`, report.Files)
+	}
+
+	if report.SuccessCount() != 2 {
+		t.Fatalf(`%d files verified instead of 2`, report.SuccessCount())
+	}
+
+	if report.BytesHashed != 24 {
+		t.Fatalf(`%d bytes hashed instead of 24`, report.BytesHashed)
+	}
+
+	if report.VerificationId != result.VerificationId {
+		t.Fatalf(`Verification id is '%s' instead of '%s'`, report.VerificationId, result.VerificationId)
+	}
+
+	// Verification ids in word form are accepted, as well.
+	verifyTestFiles(t, result.VerificationWords)
+}
+
+func TestModifiedFile(t *testing.T) {
+	makeTestFiles(t)
+
+	result := signTestFiles(t)
+
+	writeTestFile(t, `a.txt`, `Modified content of a`)
+	err := os.Remove(`b.txt`)
+	if err != nil {
+		t.Fatalf(`Could not remove file: %v`, err)
+	}
+
+	report := verifyTestFiles(t, result.VerificationId)
+	if report.ErrorCount() != 1 {
+		t.Fatalf(`%d errors instead of 1`, report.ErrorCount())
+	}
+
+	checkFileStatus(t, report.Files[0], `a.txt`, FileStatusModified)
+	checkFileStatus(t, report.Files[1], `b.txt`, FileStatusMissing)
+
+	if !report.HasWarnings() || report.MissingCount() != 1 {
+		t.Fatal(`Missing file is not reported as a warning`)
+	}
+}
+
+func TestInvalidVerificationId(t *testing.T) {
+	makeTestFiles(t)
+
+	result := signTestFiles(t)
+
+	// This is a valid verification id of other signatures.
+	_, err := Verify(context.Background(), &VerifyOptions{ResolveVerificationIds: FixedVerificationIds(`QKY0-85L1-873G-2VKF-LDE2-L393-CHQ2`)})
+	if !errors.Is(err, ErrInvalidVerificationId) {
+		t.Fatalf(`Invalid verification id not detected: %v`, err)
+	}
+
+	typoId := `X` + result.VerificationId[1:]
+	_, err = Verify(context.Background(), &VerifyOptions{ResolveVerificationIds: FixedVerificationIds(typoId)})
+	var idErr *InvalidVerificationIdError
+	if !errors.As(err, &idErr) {
+		t.Fatalf(`Typo in verification id not detected: %v`, err)
+	}
+}
+
+func TestModifiedSignaturesFile(t *testing.T) {
+	makeTestFiles(t)
+
+	result := signTestFiles(t)
+
+	content, err := os.ReadFile(DefaultSignaturesFileName)
+	if err != nil {
+		t.Fatalf(`Could not read signatures file: %v`, err)
+	}
+
+	writeTestFile(t, DefaultSignaturesFileName, strings.Replace(string(content), testContextId, `api tesT`, 1))
+
+	_, err = Verify(context.Background(), &VerifyOptions{ResolveVerificationIds: FixedVerificationIds(result.VerificationId)})
+	if !errors.Is(err, ErrSignaturesModified) {
+		t.Fatalf(`Modified signatures file not detected: %v`, err)
+	}
+}
+
+func TestVerifyFS(t *testing.T) {
+	makeTestFiles(t)
+
+	result := signTestFiles(t)
+
+	fsys := fstest.MapFS{}
+	for _, filePath := range []string{`a.txt`, `b.txt`, DefaultSignaturesFileName} {
+		addTestMapFile(t, fsys, filePath)
+	}
+
+	report, err := VerifyFS(context.Background(), fsys, DefaultSignaturesFileName, result.VerificationId)
+	if err != nil {
+		t.Fatalf(`Could not verify file system: %v`, err)
+	}
+
+	if !report.IsOk() || report.SuccessCount() != 2 {
+		t.Fatalf(`Verification of file system not ok: %v`, report.Files)
+	}
+
+	fsys[`b.txt`].Data = []byte(`Content of B`)
+
+	report, err = VerifyFS(context.Background(), fsys, DefaultSignaturesFileName, result.VerificationId)
+	if err != nil {
+		t.Fatalf(`Could not verify file system: %v`, err)
+	}
+
+	checkFileStatus(t, report.Files[0], `a.txt`, FileStatusOK)
+	checkFileStatus(t, report.Files[1], `b.txt`, FileStatusModified)
+}
+
+func TestNoFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	_, err := Sign(context.Background(), &SignOptions{ContextId: testContextId})
+	if !errors.Is(err, ErrNoFiles) {
+		t.Fatalf(`Missing files not detected: %v`, err)
+	}
+}
+
+func TestHashError(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	_, err := Sign(context.Background(), &SignOptions{ContextId: testContextId, FilePaths: []string{`missing.txt`}})
+	var hashErr *HashError
+	if !errors.As(err, &hashErr) {
+		t.Fatalf(`Hash error not returned: %v`, err)
+	}
+
+	if len(hashErr.Errors) != 1 || hashErr.Errors[0].FilePath != `missing.txt` {
+		t.Fatalf(`Wrong file errors: %v`, hashErr.Errors)
+	}
+}
+
+func TestCanceled(t *testing.T) {
+	makeTestFiles(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Sign(ctx, &SignOptions{ContextId: testContextId, FilePaths: []string{`a.txt`}})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf(`Cancellation not detected: %v`, err)
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	const count = 8
+
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := range count {
+		wg.Go(func() {
+			errs <- signAndVerifyData(i)
+		})
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// ******** Private functions ********
+
+// makeTestFiles changes to a new temporary directory and creates the test files in it.
+func makeTestFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeTestFile(t, `a.txt`, `Content of a`)
+	writeTestFile(t, `b.txt`, `Content of b`)
+}
+
+// writeTestFile writes a test file.
+func writeTestFile(t *testing.T, filePath string, content string) {
+	err := os.WriteFile(filePath, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf(`Could not write file '%s': %v`, filePath, err)
+	}
+}
+
+// addTestMapFile copies a file into a map file system.
+func addTestMapFile(t *testing.T, fsys fstest.MapFS, filePath string) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf(`Could not read file '%s': %v`, filePath, err)
+	}
+
+	var fi os.FileInfo
+	fi, err = os.Stat(filePath)
+	if err != nil {
+		t.Fatalf(`Could not get file info of '%s': %v`, filePath, err)
+	}
+
+	fsys[filePath] = &fstest.MapFile{Data: content, Mode: fi.Mode()}
+}
+
+// signTestFiles signs the test files.
+func signTestFiles(t *testing.T) *Result {
+	result, err := Sign(context.Background(), &SignOptions{
+		ContextId:    testContextId,
+		FilePaths:    []string{`a.txt`, `b.txt`},
+		WithMetadata: true,
+	})
+	if err != nil {
+		t.Fatalf(`Could not sign files: %v`, err)
+	}
+
+	return result
+}
+
+// verifyTestFiles verifies the test files.
+func verifyTestFiles(t *testing.T, verificationId string) *Report {
+	report, err := Verify(context.Background(), &VerifyOptions{ResolveVerificationIds: FixedVerificationIds(verificationId)})
+	if err != nil {
+		t.Fatalf(`Could not verify files: %v`, err)
+	}
+
+	return report
+}
+
+// checkFileStatus checks the path and the status of a file result.
+func checkFileStatus(t *testing.T, fileResult *FileResult, filePath string, status FileStatus) {
+	if fileResult.FilePath != filePath || fileResult.Status != status {
+		t.Fatalf(`File '%s' has status '%s' instead of file '%s' with status '%s'`, fileResult.FilePath, fileResult.Status, filePath, status)
+	}
+}
+
+// signAndVerifyData signs data with a signatures file of its own and verifies them.
+func signAndVerifyData(i int) error {
+	contextId := fmt.Sprintf(`context %d`, i)
+	signaturesFileName := fmt.Sprintf(`data%d-signatures.json`, i)
+	data := strings.Repeat(contextId, 1000)
+
+	result, err := Sign(context.Background(), &SignOptions{
+		ContextId:          contextId,
+		DataName:           `data.txt`,
+		DataReader:         strings.NewReader(data),
+		SignaturesFileName: signaturesFileName,
+	})
+	if err != nil {
+		return err
+	}
+
+	var report *Report
+	report, err = Verify(context.Background(), &VerifyOptions{
+		SignaturesFileName:     signaturesFileName,
+		DataName:               `data.txt`,
+		DataReader:             strings.NewReader(data),
+		ResolveVerificationIds: FixedVerificationIds(result.VerificationId),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !report.IsOk() {
+		return fmt.Errorf(`Verification of data %d failed: %v`, i, report.Files[0].Err)
+	}
+
+	return nil
+}
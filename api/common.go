@@ -0,0 +1,184 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+// Package api implements the signing and verification of files as a library.
+//
+// Sign creates a signatures file and Verify checks the files against a signatures file.
+// Both return structured results and do not print anything, so they can be embedded in other programs.
+// The functions are safe for concurrent use.
+// File paths are relative to the current directory or, if a file system is specified in the options, to its root.
+package api
+
+import (
+	"context"
+	"errors"
+	"filesigner/filehasher"
+	"filesigner/keyid"
+	"filesigner/maphelper"
+	"filesigner/signaturehandler"
+	"filesigner/stringhelper"
+	"fmt"
+	"strings"
+)
+
+// ******** Public types ********
+
+// SignatureInfo contains the data that describe a set of signatures.
+type SignatureInfo struct {
+	ContextId         string
+	PublicKeyId       string
+	Timestamp         string
+	Hostname          string
+	NotAfter          string
+	VerificationId    string
+	VerificationWords string
+}
+
+// FileError is an error that concerns a single file.
+type FileError struct {
+	FilePath string
+	Err      error
+}
+
+// HashError is returned if the hashes of some files could not be calculated.
+type HashError struct {
+	// Errors contains the errors sorted by file path.
+	Errors []*FileError
+}
+
+// ******** Public constants ********
+
+// DefaultSignaturesFileName is the name of the signatures file if no name is specified.
+const DefaultSignaturesFileName = `filesigner-signatures.json`
+
+// ******** Public variables ********
+
+// ErrNoFiles is returned if there are no files to sign.
+var ErrNoFiles = errors.New(`No files found to sign`)
+
+// ******** Public type functions ********
+
+// Error returns the error message of a file error.
+func (e *FileError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error of a file error.
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// Error returns the error message of a hash error.
+func (e *HashError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		messages[i] = fe.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// ******** Private functions ********
+
+// makeSignatureInfo returns the signature info for the given data.
+func makeSignatureInfo(
+	signatureData *signaturehandler.SignatureData,
+	publicKeyBytes []byte) SignatureInfo {
+	idData := verificationIdData(signatureData, publicKeyBytes)
+
+	return SignatureInfo{
+		ContextId:         signatureData.ContextId,
+		PublicKeyId:       keyid.KeyId(publicKeyBytes),
+		Timestamp:         signatureData.Timestamp,
+		Hostname:          signatureData.Hostname,
+		NotAfter:          signatureData.NotAfter,
+		VerificationId:    keyid.KeyId(idData...),
+		VerificationWords: keyid.KeyWords(idData...),
+	}
+}
+
+// makeVerificationHash returns the hash value that is encoded in the verification id for the given data.
+func makeVerificationHash(
+	signatureData *signaturehandler.SignatureData,
+	publicKeyBytes []byte) []byte {
+	return keyid.KeyHash(verificationIdData(signatureData, publicKeyBytes)...)
+}
+
+// verificationIdData returns the data that the verification id is calculated from.
+func verificationIdData(
+	signatureData *signaturehandler.SignatureData,
+	publicKeyBytes []byte) [][]byte {
+	return [][]byte{
+		stringhelper.UnsafeStringBytes(signatureData.ContextId),
+		publicKeyBytes,
+		stringhelper.UnsafeStringBytes(signatureData.Timestamp),
+		stringhelper.UnsafeStringBytes(signatureData.Hostname),
+	}
+}
+
+// makeHashOptions returns the hash options for the hash mode in the signature data.
+func makeHashOptions(signatureData *signaturehandler.SignatureData, contextKey []byte) *filehasher.HashOptions {
+	result := &filehasher.HashOptions{ContextKey: contextKey}
+
+	if signatureData.HashMode == signaturehandler.HashModeMerkle {
+		result.ChunkSize = signatureData.ChunkSize
+	}
+
+	result.WithMetadata = len(signatureData.FileMetadata) != 0
+	result.NoFollow = signatureData.SymlinkMode != signaturehandler.SymlinkModeFollow
+	result.RecordLinks = signatureData.SymlinkMode == signaturehandler.SymlinkModeRecord
+
+	return result
+}
+
+// hashErrors returns the hash errors in a list of hash results sorted by file path.
+func hashErrors(hashResults map[string]*filehasher.HashResult) []*FileError {
+	var result []*FileError
+
+	for _, filePath := range maphelper.SortedKeys(hashResults) {
+		hr := hashResults[filePath]
+		if hr.Err != nil {
+			result = append(result, &FileError{
+				FilePath: hr.FilePath,
+				Err:      fmt.Errorf(`Could not get hash of file '%s': %w`, hr.FilePath, hr.Err),
+			})
+		}
+	}
+
+	return result
+}
+
+// checkCanceled returns an error if the context has been canceled.
+func checkCanceled(ctx context.Context) error {
+	err := ctx.Err()
+	if err != nil {
+		return fmt.Errorf(`Operation has been canceled: %w`, err)
+	}
+
+	return nil
+}
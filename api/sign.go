@@ -0,0 +1,417 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Sign files in a file system.
+//
+
+package api
+
+import (
+	"context"
+	"errors"
+	"filesigner/archive"
+	"filesigner/base32encoding"
+	"filesigner/filehasher"
+	"filesigner/filemetadata"
+	"filesigner/filesignature"
+	"filesigner/hashsignature"
+	"filesigner/signaturefile"
+	"filesigner/signaturehandler"
+	"filesigner/signaturelog"
+	"filesigner/stretcher"
+	"filesigner/stringhelper"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ******** Public types ********
+
+// SignOptions contains the options for signing.
+type SignOptions struct {
+	// ContextId is the context id that the signatures depend on. It must not be empty.
+	ContextId string
+
+	// SignatureType is the signature algorithm. The default is Ed25519.
+	SignatureType signaturehandler.SignatureType
+
+	// FilePaths contains the paths of the files to sign.
+	FilePaths []string
+
+	// FS is the file system that the files are read from. The file paths are relative to its root.
+	// If it is nil, the files are read from the current directory.
+	FS fs.FS
+
+	// ArchivePath is the path of a zip or tar archive whose entries are signed instead of the files.
+	ArchivePath string
+
+	// IntoArchivePath is the path of a zip or tar archive that the signed files and the signatures file are written to.
+	IntoArchivePath string
+
+	// DataName is the name under which the data from DataReader are signed instead of the files.
+	DataName   string
+	DataReader io.Reader
+
+	// SignaturesFileName is the name of the signatures file. The default is DefaultSignaturesFileName.
+	SignaturesFileName string
+
+	// ChunkSize is the chunk size of the Merkle tree. If it is 0, no Merkle tree is used.
+	ChunkSize int64
+
+	// NotAfter is the time after which the signatures are no longer valid. If it is zero, they do not expire.
+	NotAfter time.Time
+
+	// WithMetadata specifies that the size and the permission bits of the files are signed, as well.
+	WithMetadata bool
+
+	// SymlinkMode specifies how symbolic links are treated.
+	SymlinkMode signaturehandler.SymlinkMode
+
+	// LogPath is the path of a signature log that an entry for the signatures file is appended to.
+	LogPath string
+}
+
+// Result contains the result of signing.
+type Result struct {
+	SignatureInfo
+
+	// SignaturesPath is the path of the file that the signatures file has been written to.
+	// This is the into-archive path, if the signatures file has been written into an archive.
+	SignaturesPath string
+
+	// FilePaths contains the sorted paths of the signed files.
+	FilePaths []string
+}
+
+// ******** Public functions ********
+
+// Sign signs the files in the options and writes the signatures file.
+// If a hash of a file could not be calculated, a *HashError is returned.
+// If there are no files to sign, an error that wraps ErrNoFiles is returned.
+func Sign(ctx context.Context, options *SignOptions) (*Result, error) {
+	err := checkSignOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	signatureData := &signaturehandler.SignatureData{
+		Timestamp:     time.Now().Format(signaturehandler.TimestampFormat),
+		SignatureType: options.SignatureType,
+		ContextId:     options.ContextId,
+		SymlinkMode:   options.SymlinkMode,
+	}
+
+	if signatureData.SignatureType == signaturehandler.SignatureTypeInvalid {
+		signatureData.SignatureType = signaturehandler.SignatureTypeEd25519
+	}
+
+	if options.ChunkSize != 0 {
+		signatureData.HashMode = signaturehandler.HashModeMerkle
+		signatureData.ChunkSize = options.ChunkSize
+	}
+
+	if !options.NotAfter.IsZero() {
+		signatureData.NotAfter = options.NotAfter.Format(signaturehandler.TimestampFormat)
+	}
+
+	signatureData.Hostname, err = os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf(`Could not get host name: %w`, err)
+	}
+
+	contextKey := stretcher.KeyFromBytes(stringhelper.UnsafeStringBytes(options.ContextId))
+
+	var archiveWriter *archive.Writer
+	if len(options.IntoArchivePath) != 0 {
+		archiveWriter, err = archive.NewWriter(options.IntoArchivePath)
+		if err != nil {
+			return nil, fmt.Errorf(`Could not create archive '%s': %w`, options.IntoArchivePath, err)
+		}
+	}
+
+	var result *Result
+	result, err = signFiles(ctx, signatureData, contextKey, options, archiveWriter)
+
+	if archiveWriter != nil && err != nil {
+		err = errors.Join(err, removeIncompleteArchive(archiveWriter, options.IntoArchivePath))
+	}
+
+	return result, err
+}
+
+// ******** Private functions ********
+
+// checkSignOptions checks that the sign options are consistent.
+func checkSignOptions(options *SignOptions) error {
+	if len(options.ContextId) == 0 {
+		return errors.New(`Context id must not be empty`)
+	}
+
+	if options.SignatureType > signaturehandler.SignatureTypeEcDsaP521 {
+		return fmt.Errorf(`Invalid signature type %d`, options.SignatureType)
+	}
+
+	if options.SymlinkMode > signaturehandler.SymlinkModeMax {
+		return fmt.Errorf(`Invalid symbolic link mode %d`, options.SymlinkMode)
+	}
+
+	if options.ChunkSize != 0 {
+		err := filehasher.CheckChunkSize(options.ChunkSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(options.DataName) != 0 && options.DataReader == nil {
+		return errors.New(`Data name must not be specified without a data reader`)
+	}
+
+	if len(options.ArchivePath) != 0 && len(options.IntoArchivePath) != 0 {
+		return errors.New(`Archive path and into-archive path must not be specified together`)
+	}
+
+	if options.FS != nil && len(options.ArchivePath) != 0 {
+		return errors.New(`File system and archive path must not be specified together`)
+	}
+
+	return nil
+}
+
+// signFiles hashes and signs the files and writes the signatures file.
+func signFiles(ctx context.Context,
+	signatureData *signaturehandler.SignatureData,
+	contextKey []byte,
+	options *SignOptions,
+	archiveWriter *archive.Writer) (*Result, error) {
+	err := checkCanceled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hashOptions := makeHashOptions(signatureData, contextKey)
+	hashOptions.WithMetadata = options.WithMetadata
+	hashOptions.FS = options.FS
+
+	var resultList map[string]*filehasher.HashResult
+	resultList, err = getSignHashes(hashOptions, options, archiveWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkCanceled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashSigner hashsignature.HashSigner
+	if signatureData.SignatureType == signaturehandler.SignatureTypeEd25519 {
+		hashSigner, err = hashsignature.NewEd25519HashSigner()
+	} else {
+		hashSigner, err = hashsignature.NewEcDsaP521HashSigner()
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`Could not create hash-signer: %w`, err)
+	}
+	defer hashSigner.Destroy()
+
+	var publicKeyBytes []byte
+	publicKeyBytes, err = hashSigner.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf(`Could not get public key bytes: %w`, err)
+	}
+	signatureData.PublicKey = base32encoding.EncodeToString(publicKeyBytes)
+
+	var successList []string
+	signatureData.FileSignatures, successList, err = filesignature.SignFileHashes(hashSigner, resultList)
+	if err != nil {
+		return nil, fmt.Errorf(`Could not sign file hashes: %w`, err)
+	}
+
+	if options.WithMetadata {
+		signatureData.FileMetadata = makeFileMetadata(resultList)
+	}
+
+	if signatureData.SymlinkMode == signaturehandler.SymlinkModeRecord {
+		signatureData.Symlinks = makeSymlinks(resultList)
+	}
+
+	// The format depends on the extension fields, so it can only be set when all of them are set.
+	signatureData.SetFormat()
+
+	err = signatureData.Sign(hashSigner, contextKey)
+	if err != nil {
+		return nil, fmt.Errorf(`Could not sign signatures file data: %w`, err)
+	}
+
+	result := &Result{
+		SignatureInfo: makeSignatureInfo(signatureData, publicKeyBytes),
+		FilePaths:     successList,
+	}
+
+	// The signatures are logged before they are written, so that there is no signatures file without a log entry.
+	if len(options.LogPath) != 0 {
+		err = appendToSignatureLog(options.LogPath, signatureData, result.VerificationId)
+		if err != nil {
+			return nil, fmt.Errorf(`Could not append to signature log '%s': %w`, options.LogPath, err)
+		}
+	}
+
+	signaturesFileName := options.SignaturesFileName
+	if len(signaturesFileName) == 0 {
+		signaturesFileName = DefaultSignaturesFileName
+	}
+
+	if archiveWriter == nil {
+		result.SignaturesPath = signaturesFileName
+		err = signaturefile.WriteJson(signaturesFileName, signatureData)
+	} else {
+		result.SignaturesPath = options.IntoArchivePath
+		err = writeSignaturesIntoArchive(archiveWriter, signaturesFileName, signatureData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`Error writing signatures file '%s': %w`, result.SignaturesPath, err)
+	}
+
+	return result, nil
+}
+
+// getSignHashes calculates the hashes of the files to sign.
+func getSignHashes(hashOptions *filehasher.HashOptions,
+	options *SignOptions,
+	archiveWriter *archive.Writer) (map[string]*filehasher.HashResult, error) {
+	var err error
+	var resultList map[string]*filehasher.HashResult
+
+	switch {
+	case len(options.DataName) != 0:
+		resultList = filehasher.StreamHashes(filepath.FromSlash(options.DataName), options.DataReader, hashOptions)
+
+	case len(options.ArchivePath) != 0:
+		resultList, err = filehasher.ArchiveHashes(options.ArchivePath, hashOptions)
+		if err != nil {
+			return nil, fmt.Errorf(`Could not read archive '%s': %w`, options.ArchivePath, err)
+		}
+
+		if len(resultList) == 0 {
+			return nil, fmt.Errorf(`%w in archive '%s'`, ErrNoFiles, options.ArchivePath)
+		}
+
+	case len(options.FilePaths) == 0:
+		return nil, ErrNoFiles
+
+	case archiveWriter != nil:
+		resultList, err = filehasher.FileHashesIntoArchive(options.FilePaths, hashOptions, archiveWriter)
+		if err != nil {
+			return nil, fmt.Errorf(`Could not write archive '%s': %w`, options.IntoArchivePath, err)
+		}
+
+	default:
+		resultList = filehasher.FileHashes(options.FilePaths, hashOptions)
+	}
+
+	fileErrors := hashErrors(resultList)
+	if len(fileErrors) != 0 {
+		return nil, &HashError{Errors: fileErrors}
+	}
+
+	return resultList, nil
+}
+
+// makeFileMetadata collects the metadata from the hash results with the file paths in the signatures file as keys.
+func makeFileMetadata(resultList map[string]*filehasher.HashResult) map[string]*filemetadata.Metadata {
+	result := make(map[string]*filemetadata.Metadata, len(resultList))
+	for filePath, hashResult := range resultList {
+		result[filepath.ToSlash(filePath)] = hashResult.Metadata
+	}
+
+	return result
+}
+
+// makeSymlinks collects the targets of the recorded symbolic links from the hash results
+// with the file paths in the signatures file as keys.
+func makeSymlinks(resultList map[string]*filehasher.HashResult) map[string]string {
+	result := make(map[string]string)
+	for filePath, hashResult := range resultList {
+		if len(hashResult.LinkTarget) != 0 {
+			result[filepath.ToSlash(filePath)] = hashResult.LinkTarget
+		}
+	}
+
+	return result
+}
+
+// appendToSignatureLog appends an entry for the signature data to the signature log.
+// Nothing is appended to a signature log with a broken hash chain.
+func appendToSignatureLog(logPath string, signatureData *signaturehandler.SignatureData, verificationId string) error {
+	signatureLog, err := signaturelog.Load(logPath)
+	if err != nil {
+		return err
+	}
+
+	var entry *signaturelog.Entry
+	entry, err = signaturelog.NewEntry(verificationId,
+		signatureData.ContextId,
+		signatureData.Timestamp,
+		signatureData.Hostname,
+		signatureData.DataSignature)
+	if err != nil {
+		return err
+	}
+
+	return signatureLog.Append(entry)
+}
+
+// writeSignaturesIntoArchive writes the signatures file as the last entry of an archive and closes the archive.
+func writeSignaturesIntoArchive(archiveWriter *archive.Writer,
+	signaturesFileName string,
+	signatureData *signaturehandler.SignatureData) error {
+	jsonOutput, err := signaturefile.JsonBytes(signatureData)
+	if err != nil {
+		return err
+	}
+
+	err = archiveWriter.WriteEntry(signaturesFileName, jsonOutput, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return archiveWriter.Close()
+}
+
+// removeIncompleteArchive closes and deletes an archive that could not be written completely.
+func removeIncompleteArchive(archiveWriter *archive.Writer, archivePath string) error {
+	_ = archiveWriter.Close()
+
+	err := os.Remove(archivePath)
+	if err != nil {
+		return fmt.Errorf(`Could not delete incomplete archive '%s': %w`, archivePath, err)
+	}
+
+	return nil
+}
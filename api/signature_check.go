@@ -0,0 +1,128 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Check expiry of the revocation list.
+//
+
+package api
+
+import (
+	"errors"
+	"filesigner/revocation"
+	"filesigner/signaturehandler"
+	"fmt"
+	"time"
+)
+
+// ******** Public types ********
+
+// RevokedError is returned if the verification id of the signatures file has been revoked.
+type RevokedError struct {
+	VerificationId string
+	Date           string
+	Reason         string
+}
+
+// ******** Public variables ********
+
+// ErrExpired is returned if the signatures have expired at the verification time.
+var ErrExpired = errors.New(`Signatures have expired`)
+
+// ErrInvalidTimestamp is reported if the signature timestamp has an invalid format.
+var ErrInvalidTimestamp = errors.New(`Signature timestamp has an invalid format`)
+
+// ErrFutureTimestamp is reported if the signatures have been created after the verification time.
+var ErrFutureTimestamp = errors.New(`Signature timestamp lies in the future relative to the verification time`)
+
+// ******** Public type functions ********
+
+// Error returns the error message of a revoked error.
+func (e *RevokedError) Error() string {
+	return fmt.Sprintf(`Verification id '%s' has been revoked at %s: %s`, e.VerificationId, e.Date, e.Reason)
+}
+
+// ******** Private functions ********
+
+// checkRevocation checks that a verification id is not in the revocation list with the given path and list id.
+// The list must not have expired, as an old list may not contain the latest revocations.
+func checkRevocation(listPath string, listId string, verificationHash []byte) error {
+	list, err := revocation.Load(listPath)
+	if err != nil {
+		return fmt.Errorf(`Could not read revocation list '%s': %w`, listPath, err)
+	}
+
+	var hasId bool
+	hasId, err = list.HasId(listId)
+	if err != nil {
+		return fmt.Errorf(`List id '%s' is invalid: %w`, listId, err)
+	}
+
+	if !hasId {
+		return errors.New(`Revocation list has an invalid list id`)
+	}
+
+	// The expiry is checked against the current time, not the verification time,
+	// as the list has to be current now.
+	err = list.CheckExpiry(time.Now())
+	if err != nil {
+		return err
+	}
+
+	r := list.Find(verificationHash)
+	if r != nil {
+		return &RevokedError{VerificationId: r.Id, Date: r.Date, Reason: r.Reason}
+	}
+
+	return nil
+}
+
+// checkSignatureTimes checks that the signatures have not expired at the verification time.
+// It returns a warning if the signatures have been created after the verification time.
+func checkSignatureTimes(signatureData *signaturehandler.SignatureData, verificationTime time.Time) (error, error) {
+	var warning error
+
+	timestamp, err := time.Parse(signaturehandler.TimestampFormat, signatureData.Timestamp)
+	if err != nil {
+		warning = fmt.Errorf(`%w: '%s'`, ErrInvalidTimestamp, signatureData.Timestamp)
+	} else if timestamp.After(verificationTime) {
+		warning = fmt.Errorf(`%w %s`, ErrFutureTimestamp, verificationTime.Format(signaturehandler.TimestampFormat))
+	}
+
+	if len(signatureData.NotAfter) == 0 {
+		return warning, nil
+	}
+
+	// The format of the expiry time has been checked when the signatures file has been read.
+	notAfter, _ := time.Parse(signaturehandler.TimestampFormat, signatureData.NotAfter)
+	if verificationTime.After(notAfter) {
+		return warning, fmt.Errorf(`%w at %s, verification time is %s`,
+			ErrExpired,
+			signatureData.NotAfter,
+			verificationTime.Format(signaturehandler.TimestampFormat))
+	}
+
+	return warning, nil
+}
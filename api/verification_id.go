@@ -0,0 +1,103 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package api
+
+import (
+	"bytes"
+	"errors"
+	"filesigner/keyid"
+	"fmt"
+)
+
+// ******** Public types ********
+
+// VerificationIdResolver returns the valid verification ids for the context id of a signatures file.
+// A verification id may be given either as a key id or in word form.
+type VerificationIdResolver func(contextId string) ([]string, error)
+
+// InvalidVerificationIdError is returned if a verification id can not be decoded.
+// Err wraps base32encoding.ErrKeyTypos or a *base32encoding.KeyTypoError, if the verification id has typos.
+type InvalidVerificationIdError struct {
+	VerificationId string
+	Err            error
+}
+
+// ******** Public variables ********
+
+// ErrInvalidVerificationId is returned if none of the valid verification ids matches the signatures file.
+var ErrInvalidVerificationId = errors.New(`Invalid verification id`)
+
+// ******** Public functions ********
+
+// FixedVerificationIds returns a verification id resolver that returns the given verification ids for all context ids.
+func FixedVerificationIds(verificationIds ...string) VerificationIdResolver {
+	return func(string) ([]string, error) {
+		return verificationIds, nil
+	}
+}
+
+// ******** Public type functions ********
+
+// Error returns the error message of an invalid verification id error.
+func (e *InvalidVerificationIdError) Error() string {
+	return fmt.Sprintf(`Verification id '%s' is invalid: %v`, e.VerificationId, e.Err)
+}
+
+// Unwrap returns the underlying error of an invalid verification id error.
+func (e *InvalidVerificationIdError) Unwrap() error {
+	return e.Err
+}
+
+// ******** Private functions ********
+
+// checkVerificationId checks that one of the verification ids of the resolver encodes the verification hash.
+func checkVerificationId(resolver VerificationIdResolver, contextId string, verificationHash []byte) error {
+	if resolver == nil {
+		return errors.New(`No verification id resolver specified`)
+	}
+
+	validVerificationIds, err := resolver(contextId)
+	if err != nil {
+		return fmt.Errorf(`Could not get verification id: %w`, err)
+	}
+
+	for _, verificationId := range validVerificationIds {
+		var idHash []byte
+		idHash, err = keyid.KeyHashFromId(verificationId)
+		if err != nil {
+			return &InvalidVerificationIdError{VerificationId: verificationId, Err: err}
+		}
+
+		if bytes.Equal(idHash, verificationHash) {
+			return nil
+		}
+	}
+
+	return ErrInvalidVerificationId
+}
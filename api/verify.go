@@ -0,0 +1,705 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.3.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Verify files in a file system.
+//    2026-10-19: V1.2.0: Verify only some of the files.
+//    2026-10-19: V1.3.0: Report number of hashed bytes.
+//
+
+package api
+
+import (
+	"context"
+	"errors"
+	"filesigner/archive"
+	"filesigner/base32encoding"
+	"filesigner/filehasher"
+	"filesigner/filemetadata"
+	"filesigner/filesignature"
+	"filesigner/hashsignature"
+	"filesigner/maphelper"
+	"filesigner/signaturefile"
+	"filesigner/signaturehandler"
+	"filesigner/stretcher"
+	"filesigner/stringhelper"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ******** Public types ********
+
+// VerifyOptions contains the options for verifying.
+type VerifyOptions struct {
+	// SignaturesFileName is the name of the signatures file. The default is DefaultSignaturesFileName.
+	SignaturesFileName string
+
+	// FS is the file system that the signatures file and the files are read from.
+	// If it is nil, they are read from the current directory.
+	FS fs.FS
+
+	// FilePaths restricts the verification to these files of the signatures file. If it is empty, all files are verified.
+	// Files that are not in the signatures file are ignored.
+	FilePaths []string
+
+	// ArchivePath is the path of a zip or tar archive whose entries are verified instead of the files.
+	// If the archive contains the signatures file, this embedded signatures file is used
+	// and the archive must not contain any files that are not signed.
+	ArchivePath string
+
+	// DataName is the name under which the data from DataReader have been signed.
+	// If it is specified, only these data are verified.
+	DataName   string
+	DataReader io.Reader
+
+	// ResolveVerificationIds returns the valid verification ids. It must be specified.
+	ResolveVerificationIds VerificationIdResolver
+
+	// RevocationsPath is the path of a revocation list with the list id RevocationsId that is checked, if it is specified.
+	RevocationsPath string
+	RevocationsId   string
+
+	// VerificationTime is the time at which the signatures are verified. The default is the current time.
+	VerificationTime time.Time
+}
+
+// FileStatus is the result of the verification of a file.
+type FileStatus byte
+
+// FileResult contains the result of the verification of a file.
+type FileResult struct {
+	FilePath string
+	Status   FileStatus
+	// Err describes the problem, if the status is not FileStatusOK.
+	Err error
+}
+
+// Report contains the result of a verification.
+type Report struct {
+	SignatureInfo
+
+	// SignaturesFileName is the name of the signatures file.
+	SignaturesFileName string
+
+	// EmbeddingArchivePath is the path of the archive that the signatures file has been read from.
+	// It is empty, if the signatures file has not been embedded in an archive.
+	EmbeddingArchivePath string
+
+	// Files contains the results of the files sorted by file path.
+	Files []*FileResult
+
+	// Warnings contains the warnings that do not concern a single file.
+	Warnings []error
+
+	// BytesHashed is the number of bytes that have been read to calculate the hash values of the files.
+	BytesHashed int64
+
+	// ModesNotCompared is true if the recorded file modes could not be compared on this platform.
+	ModesNotCompared bool
+}
+
+// ******** Public constants ********
+
+// File status values.
+const (
+	// FileStatusOK means that the file has been verified successfully.
+	FileStatusOK FileStatus = iota
+	// FileStatusMissing means that the file in the signatures file does not exist.
+	FileStatusMissing
+	// FileStatusDirectory means that the file in the signatures file is a directory.
+	FileStatusDirectory
+	// FileStatusFailed means that the file could not be checked.
+	FileStatusFailed
+	// FileStatusModified means that the content of the file has been modified.
+	FileStatusModified
+	// FileStatusMetadataModified means that the size or the permission bits of the file have been modified.
+	FileStatusMetadataModified
+	// FileStatusSymlinkChanged means that the file is no longer the recorded symbolic link or has become a symbolic link.
+	FileStatusSymlinkChanged
+	// FileStatusUnsigned means that the archive entry is not in the embedded signatures file.
+	FileStatusUnsigned
+)
+
+// ******** Public variables ********
+
+// ErrSignaturesModified is returned if the signatures file has been modified.
+var ErrSignaturesModified = errors.New(`Signatures file has been modified`)
+
+// ErrNoFilesPresent is reported if none of the files in the signatures file is present.
+var ErrNoFilesPresent = errors.New(`No files from signatures file present`)
+
+// ErrMetadataNotChecked is reported if the metadata of some files could not be checked.
+var ErrMetadataNotChecked = errors.New(`Metadata could not be checked`)
+
+// ******** Private variables ********
+
+// fileStatusTexts contains the texts of the file status values.
+var fileStatusTexts = []string{
+	`ok`,
+	`missing`,
+	`directory`,
+	`failed`,
+	`modified`,
+	`metadata modified`,
+	`symbolic link changed`,
+	`unsigned`,
+}
+
+// ******** Public functions ********
+
+// Verify verifies the files against the signatures file.
+// Problems with single files are reported in the returned report.
+// An error is returned if the signatures file itself can not be verified.
+// In this case the error is ErrSignaturesModified, ErrInvalidVerificationId, ErrExpired,
+// an *InvalidVerificationIdError, a *RevokedError or an error that describes the problem.
+func Verify(ctx context.Context, options *VerifyOptions) (*Report, error) {
+	if options.FS != nil && len(options.ArchivePath) != 0 {
+		return nil, errors.New(`File system and archive path must not be specified together`)
+	}
+
+	signaturesFileName := options.SignaturesFileName
+	if len(signaturesFileName) == 0 {
+		signaturesFileName = DefaultSignaturesFileName
+	}
+
+	signatureData, isEmbedded, err := readSignatureData(options.FS, signaturesFileName, options.ArchivePath)
+	if err != nil {
+		return nil, fmt.Errorf(`Error reading signatures file: %w`, err)
+	}
+
+	report := &Report{SignaturesFileName: signaturesFileName}
+	if isEmbedded {
+		report.EmbeddingArchivePath = options.ArchivePath
+	}
+
+	var publicKeyBytes []byte
+	publicKeyBytes, err = base32encoding.DecodeFromString(signatureData.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf(`Could not convert public key to bytes: %w`, err)
+	}
+
+	var hashVerifier hashsignature.HashVerifier
+	contextKey := stretcher.KeyFromBytes(stringhelper.UnsafeStringBytes(signatureData.ContextId))
+	hashVerifier, err = verifySignatureData(signatureData, publicKeyBytes, contextKey)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationHash := makeVerificationHash(signatureData, publicKeyBytes)
+	err = checkVerificationId(options.ResolveVerificationIds, signatureData.ContextId, verificationHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(options.RevocationsPath) != 0 {
+		err = checkRevocation(options.RevocationsPath, options.RevocationsId, verificationHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report.SignatureInfo = makeSignatureInfo(signatureData, publicKeyBytes)
+
+	verificationTime := options.VerificationTime
+	if verificationTime.IsZero() {
+		verificationTime = time.Now()
+	}
+
+	var warning error
+	warning, err = checkSignatureTimes(signatureData, verificationTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if warning != nil {
+		report.Warnings = append(report.Warnings, warning)
+	}
+
+	err = checkCanceled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = report.verifyFiles(contextKey, signatureData, hashVerifier, options, isEmbedded)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// VerifyFS verifies the files in a file system against the signatures file with the given name in the same file system.
+// The signatures file must have the given verification id.
+// Problems with single files are reported in the returned report.
+// The errors are the same as those of Verify.
+func VerifyFS(ctx context.Context, fsys fs.FS, signaturesFileName string, verificationId string) (*Report, error) {
+	return Verify(ctx, &VerifyOptions{
+		SignaturesFileName:     signaturesFileName,
+		FS:                     fsys,
+		ResolveVerificationIds: FixedVerificationIds(verificationId),
+	})
+}
+
+// ******** Public type functions ********
+
+// String returns the text of a file status.
+func (fs FileStatus) String() string {
+	if int(fs) < len(fileStatusTexts) {
+		return fileStatusTexts[fs]
+	}
+
+	return fmt.Sprintf(`FileStatus(%d)`, fs)
+}
+
+// IsError checks if a file status means that the verification of the file has failed.
+func (fs FileStatus) IsError() bool {
+	return fs != FileStatusOK && !fs.IsWarning()
+}
+
+// IsWarning checks if a file status means that the file could not be verified, as it is not present.
+func (fs FileStatus) IsWarning() bool {
+	return fs == FileStatusMissing || fs == FileStatusDirectory
+}
+
+// SuccessCount returns the number of files that have been verified successfully.
+func (r *Report) SuccessCount() int {
+	result := 0
+	for _, fr := range r.Files {
+		if fr.Status == FileStatusOK {
+			result++
+		}
+	}
+
+	return result
+}
+
+// ErrorCount returns the number of files whose verification has failed.
+func (r *Report) ErrorCount() int {
+	result := 0
+	for _, fr := range r.Files {
+		if fr.Status.IsError() {
+			result++
+		}
+	}
+
+	return result
+}
+
+// HasWarnings checks if there are warnings in the report.
+func (r *Report) HasWarnings() bool {
+	if len(r.Warnings) != 0 {
+		return true
+	}
+
+	for _, fr := range r.Files {
+		if fr.Status.IsWarning() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MissingCount returns the number of files that could not be verified, as they are not present.
+func (r *Report) MissingCount() int {
+	result := 0
+	for _, fr := range r.Files {
+		if fr.Status.IsWarning() {
+			result++
+		}
+	}
+
+	return result
+}
+
+// IsOk checks if all files have been verified successfully and there are no warnings.
+func (r *Report) IsOk() bool {
+	return r.ErrorCount() == 0 && !r.HasWarnings()
+}
+
+// ******** Private type functions ********
+
+// addFileResult adds the result of a file to the report.
+func (r *Report) addFileResult(filePath string, status FileStatus, err error) {
+	r.Files = append(r.Files, &FileResult{FilePath: filePath, Status: status, Err: err})
+}
+
+// verifyFiles verifies the signatures of the files in the signature data and adds the results to the report.
+// If isEmbedded is true, the signatures file has been read from the archive
+// and all other entries of the archive must be signed.
+// If a data name is given, only the data from the data reader are verified.
+func (r *Report) verifyFiles(contextKey []byte,
+	signatureData *signaturehandler.SignatureData,
+	hashVerifier hashsignature.HashVerifier,
+	options *VerifyOptions,
+	isEmbedded bool) error {
+	hashOptions := makeHashOptions(signatureData, contextKey)
+	hashOptions.FS = options.FS
+
+	var hashList map[string]*filehasher.HashResult
+	switch {
+	case len(options.DataName) != 0:
+		if options.DataReader == nil {
+			return errors.New(`Data name must not be specified without a data reader`)
+		}
+
+		_, isSigned := signatureData.FileSignatures[options.DataName]
+		if !isSigned {
+			return fmt.Errorf(`Data with name '%s' are not in signatures file`, options.DataName)
+		}
+
+		hashList = filehasher.StreamHashes(filepath.FromSlash(options.DataName), options.DataReader, hashOptions)
+
+	case len(options.ArchivePath) == 0:
+		hashList = r.getFileHashes(hashOptions, signatureData, options.FilePaths)
+
+	default:
+		var err error
+		hashList, err = filehasher.ArchiveHashes(options.ArchivePath, hashOptions)
+		if err != nil {
+			return fmt.Errorf(`Could not read archive '%s': %w`, options.ArchivePath, err)
+		}
+
+		if isEmbedded {
+			r.checkUnsignedArchiveEntries(signatureData.FileSignatures, hashList, r.SignaturesFileName)
+		}
+
+		hashList = r.getSignedArchiveEntries(signatureData.FileSignatures, hashList)
+	}
+
+	if len(hashList) == 0 {
+		r.Warnings = append(r.Warnings, ErrNoFilesPresent)
+		r.sortFiles()
+		return nil
+	}
+
+	for _, hashResult := range hashList {
+		r.BytesHashed += hashResult.ByteCount
+	}
+
+	for _, fe := range hashErrors(hashList) {
+		r.addFileResult(fe.FilePath, FileStatusFailed, fe.Err)
+		delete(hashList, fe.FilePath)
+	}
+
+	// Files whose type or link target has changed are reported separately and not verified.
+	if signatureData.SymlinkMode == signaturehandler.SymlinkModeRecord {
+		r.checkSymlinks(signatureData.Symlinks, hashList)
+	}
+
+	successList, errorList := filesignature.VerifyFileHashes(hashVerifier, signatureData.FileSignatures, signatureData.FileMetadata, hashList)
+	for filePath, err := range errorList {
+		r.addFileResult(filePath, FileStatusModified, err)
+	}
+
+	// Metadata mismatches are reported separately from content modifications.
+	successList = r.checkFileMetadata(successList, signatureData.FileMetadata, hashList)
+
+	for _, filePath := range successList {
+		r.addFileResult(filePath, FileStatusOK, nil)
+	}
+
+	r.sortFiles()
+
+	return nil
+}
+
+// sortFiles sorts the file results by file path.
+func (r *Report) sortFiles() {
+	slices.SortFunc(r.Files, func(a *FileResult, b *FileResult) int {
+		return strings.Compare(a.FilePath, b.FilePath)
+	})
+}
+
+// getFileHashes calculates the hashes of the files in the signature data that exist.
+// If selectedPaths is not empty, only these files are hashed.
+func (r *Report) getFileHashes(hashOptions *filehasher.HashOptions,
+	signatureData *signaturehandler.SignatureData,
+	selectedPaths []string) map[string]*filehasher.HashResult {
+	signedPaths := maphelper.Keys(signatureData.FileSignatures)
+	if len(selectedPaths) != 0 {
+		signedPaths = selectSignedPaths(signatureData.FileSignatures, selectedPaths)
+	}
+
+	filePaths := r.getExistingFiles(signedPaths, hashOptions.FS, hashOptions.NoFollow)
+
+	if len(filePaths) == 0 {
+		return nil
+	}
+
+	return filehasher.FileHashes(filePaths, hashOptions)
+}
+
+// getExistingFiles gets the files from a signature list that exist in the file system that is to be verified.
+// If noFollow is set, symbolic links are not followed, so a link with a missing target exists.
+func (r *Report) getExistingFiles(filePaths []string, fsys fs.FS, noFollow bool) []string {
+	result := make([]string, 0, len(filePaths))
+	for _, fp := range filePaths {
+		nfp := filepath.FromSlash(fp)
+		fi, err := statFile(fsys, nfp, noFollow)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			r.addFileResult(nfp, FileStatusMissing, fmt.Errorf(`File '%s' in signatures file does not exist`, nfp))
+
+		case err != nil:
+			r.addFileResult(nfp, FileStatusFailed, fmt.Errorf(`Error checking if file '%s' in signatures file exists: %w`, nfp, err))
+
+		case fi.IsDir():
+			r.addFileResult(nfp, FileStatusDirectory, fmt.Errorf(`'%s' in signatures file is a directory`, nfp))
+
+		default:
+			result = append(result, nfp)
+		}
+	}
+
+	return result
+}
+
+// getSignedArchiveEntries gets the hashes of the archive entries that are present in the signatures file.
+// Files in the signatures file that do not exist in the archive are reported as missing.
+func (r *Report) getSignedArchiveEntries(fileSignatures map[string]string,
+	archiveHashList map[string]*filehasher.HashResult) map[string]*filehasher.HashResult {
+	result := make(map[string]*filehasher.HashResult, len(fileSignatures))
+	for _, fp := range maphelper.SortedKeys(fileSignatures) {
+		nfp := filepath.FromSlash(fp)
+		hashResult, isPresent := archiveHashList[nfp]
+		if isPresent {
+			result[nfp] = hashResult
+		} else {
+			r.addFileResult(nfp, FileStatusMissing, fmt.Errorf(`File '%s' in signatures file does not exist in archive`, nfp))
+		}
+	}
+
+	return result
+}
+
+// checkUnsignedArchiveEntries reports all entries of an archive that are not in the signatures file.
+// The embedded signatures file itself is not reported.
+func (r *Report) checkUnsignedArchiveEntries(fileSignatures map[string]string,
+	archiveHashList map[string]*filehasher.HashResult,
+	embeddedFileName string) {
+	for _, nfp := range maphelper.SortedKeys(archiveHashList) {
+		fp := filepath.ToSlash(nfp)
+		if fp == embeddedFileName {
+			continue
+		}
+
+		_, isSigned := fileSignatures[fp]
+		if !isSigned {
+			r.addFileResult(nfp, FileStatusUnsigned, fmt.Errorf(`File '%s' in archive is not in signatures file`, nfp))
+		}
+	}
+}
+
+// checkSymlinks checks that the files that have been recorded as symbolic links are still links
+// with the same target and that no other file has been replaced by a link.
+// Files that do not pass this check are removed from the hash list.
+func (r *Report) checkSymlinks(symlinks map[string]string, hashList map[string]*filehasher.HashResult) {
+	for _, filePath := range maphelper.SortedKeys(hashList) {
+		linkTarget := hashList[filePath].LinkTarget
+		recordedTarget, isRecorded := symlinks[filepath.ToSlash(filePath)]
+
+		var err error
+		switch {
+		case isRecorded && len(linkTarget) == 0:
+			err = fmt.Errorf(`File '%s' is no longer a symbolic link`, filePath)
+
+		case !isRecorded && len(linkTarget) != 0:
+			err = fmt.Errorf(`File '%s' has been replaced by a symbolic link to '%s'`, filePath, linkTarget)
+
+		case linkTarget != recordedTarget:
+			err = fmt.Errorf(`Symbolic link '%s' points to '%s' instead of '%s'`, filePath, linkTarget, recordedTarget)
+
+		default:
+			continue
+		}
+
+		r.addFileResult(filePath, FileStatusSymlinkChanged, err)
+		delete(hashList, filePath)
+	}
+}
+
+// checkFileMetadata compares the recorded metadata of the files whose content has been verified
+// with their actual metadata. It returns the files whose metadata match.
+func (r *Report) checkFileMetadata(successList []string,
+	fileMetadata map[string]*filemetadata.Metadata,
+	hashList map[string]*filehasher.HashResult) []string {
+	if len(fileMetadata) == 0 {
+		return successList
+	}
+
+	result := make([]string, 0, len(successList))
+	notCheckedCount := 0
+	for _, filePath := range successList {
+		recorded := fileMetadata[filepath.ToSlash(filePath)]
+		actual := hashList[filePath].Metadata
+		if recorded == nil || actual == nil {
+			if recorded != nil {
+				notCheckedCount++
+			}
+
+			result = append(result, filePath)
+			continue
+		}
+
+		if len(recorded.Mode) != 0 && len(actual.Mode) == 0 {
+			r.ModesNotCompared = true
+		}
+
+		differences := filemetadata.Differences(recorded, actual)
+		if len(differences) == 0 {
+			result = append(result, filePath)
+			continue
+		}
+
+		r.addFileResult(filePath,
+			FileStatusMetadataModified,
+			fmt.Errorf(`Metadata of file '%s' have been modified: %s`, filePath, strings.Join(differences, `, `)))
+	}
+
+	if notCheckedCount > 0 {
+		r.Warnings = append(r.Warnings, fmt.Errorf(`%w for %d files`, ErrMetadataNotChecked, notCheckedCount))
+	}
+
+	return result
+}
+
+// ******** Private functions ********
+
+// readSignatureData reads the signature data from the signatures file.
+// If a file system is given, the signatures file is read from it.
+// If an archive path is given and the archive contains the signatures file, the embedded one is read.
+// The returned flag is true if the signature data have been read from the archive.
+func readSignatureData(fsys fs.FS, signaturesFileName string, archivePath string) (*signaturehandler.SignatureData, bool, error) {
+	if fsys != nil {
+		signatureData, err := signaturefile.ReadJsonFS(fsys, signaturesFileName)
+
+		return signatureData, false, err
+	}
+
+	if len(archivePath) != 0 {
+		signatureData, err := signaturefile.ReadJsonFromArchive(archivePath, signaturesFileName)
+		if err == nil {
+			_, coversItself := signatureData.FileSignatures[signaturesFileName]
+			if coversItself {
+				return nil, true, fmt.Errorf(`Signatures file '%s' in archive '%s' contains a signature for itself`, signaturesFileName, archivePath)
+			}
+
+			return signatureData, true, nil
+		}
+
+		if !errors.Is(err, archive.ErrEntryNotFound) {
+			return nil, true, err
+		}
+	}
+
+	signatureData, err := signaturefile.ReadJson(signaturesFileName)
+
+	return signatureData, false, err
+}
+
+// verifySignatureData verifies the data signature of the signatures file and returns the hash verifier
+// for the file signatures.
+func verifySignatureData(signatureData *signaturehandler.SignatureData,
+	publicKeyBytes []byte,
+	contextKey []byte) (hashsignature.HashVerifier, error) {
+	dataSignature, err := base32encoding.DecodeFromString(signatureData.DataSignature)
+	if err != nil {
+		return nil, fmt.Errorf(`Could not convert data signature to bytes: %w`, err)
+	}
+
+	var hashVerifier hashsignature.HashVerifier
+	hashVerifier, err = getHashVerifier(signatureData, publicKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var ok bool
+	ok, err = signatureData.Verify(hashVerifier, contextKey, dataSignature)
+	if err != nil {
+		return nil, fmt.Errorf(`Error verifying signatures file data signature: %w`, err)
+	}
+
+	if !ok {
+		return nil, ErrSignaturesModified
+	}
+
+	return hashVerifier, nil
+}
+
+// getHashVerifier constructs the hash verifier from the signature data.
+func getHashVerifier(signatureData *signaturehandler.SignatureData, publicKeyBytes []byte) (hashsignature.HashVerifier, error) {
+	var err error
+	var hashVerifier hashsignature.HashVerifier
+	if signatureData.SignatureType == signaturehandler.SignatureTypeEd25519 {
+		hashVerifier, err = hashsignature.NewEd25519HashVerifier(publicKeyBytes)
+	} else {
+		hashVerifier, err = hashsignature.NewEcDsaP521HashVerifier(publicKeyBytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`Could not create hash verifier: %w`, err)
+	}
+
+	return hashVerifier, nil
+}
+
+// selectSignedPaths returns the paths in the signatures file of the selected files that are in the signatures file.
+func selectSignedPaths(fileSignatures map[string]string, selectedPaths []string) []string {
+	result := make([]string, 0, len(selectedPaths))
+	for _, selectedPath := range selectedPaths {
+		fp := filepath.ToSlash(filepath.Clean(selectedPath))
+		_, isSigned := fileSignatures[fp]
+		if isSigned && !slices.Contains(result, fp) {
+			result = append(result, fp)
+		}
+	}
+
+	return result
+}
+
+// statFile returns the file info of a file in a file system or, if it is nil, in the current directory.
+// If noFollow is set, symbolic links are not followed.
+func statFile(fsys fs.FS, filePath string, noFollow bool) (fs.FileInfo, error) {
+	if fsys != nil {
+		name := filepath.ToSlash(filePath)
+		if noFollow {
+			return fs.Lstat(fsys, name)
+		}
+
+		return fs.Stat(fsys, name)
+	}
+
+	if noFollow {
+		return os.Lstat(filePath)
+	}
+
+	return os.Stat(filePath)
+}
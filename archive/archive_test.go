@@ -0,0 +1,269 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Test writer and reading of single entries.
+//
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ******** Private variables ********
+
+// testEntries contains the entries of the test archives.
+var testEntries = map[string]string{
+	`a.txt`:     `Content of a`,
+	`dir/b.txt`: `Content of b`,
+	`dir/c.bin`: strings.Repeat(`c`, 100_000),
+}
+
+// ******** Test functions ********
+
+func TestZip(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), `test.zip`)
+	writeTestZip(t, archivePath, `./`)
+
+	checkEntries(t, archivePath)
+}
+
+func TestTar(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), `test.tar`)
+	writeTestTar(t, archivePath, false, nil)
+
+	checkEntries(t, archivePath)
+}
+
+func TestTarGz(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), `test.TGZ`)
+	writeTestTar(t, archivePath, true, nil)
+
+	checkEntries(t, archivePath)
+}
+
+func TestUnknownType(t *testing.T) {
+	err := ForEachEntry(`test.rar`, func(string, io.Reader) error { return nil })
+	if err == nil {
+		t.Fatal(`Unknown archive type not detected`)
+	}
+}
+
+func TestOutsidePath(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), `test.zip`)
+	writeTestZip(t, archivePath, `x/../../`)
+
+	err := ForEachEntry(archivePath, func(string, io.Reader) error { return nil })
+	if err == nil {
+		t.Fatal(`Entry outside of archive not detected`)
+	}
+}
+
+func TestDuplicateEntry(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), `test.tar`)
+	writeTestTar(t, archivePath, false, &tar.Header{Name: `./a.txt`, Typeflag: tar.TypeReg})
+
+	err := ForEachEntry(archivePath, func(string, io.Reader) error { return nil })
+	if err == nil {
+		t.Fatal(`Duplicate entry not detected`)
+	}
+}
+
+func TestSymlinkEntry(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), `test.tar.gz`)
+	writeTestTar(t, archivePath, true, &tar.Header{Name: `link`, Typeflag: tar.TypeSymlink, Linkname: `a.txt`})
+
+	err := ForEachEntry(archivePath, func(string, io.Reader) error { return nil })
+	if err == nil {
+		t.Fatal(`Symbolic link entry not detected`)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	for _, archiveName := range []string{`test.zip`, `test.tar`, `test.tar.gz`} {
+		archivePath := filepath.Join(t.TempDir(), archiveName)
+
+		w, err := NewWriter(archivePath)
+		if err != nil {
+			t.Fatalf(`Could not create writer for '%s': %v`, archiveName, err)
+		}
+
+		for entryPath, content := range testEntries {
+			err = w.WriteEntry(entryPath, []byte(content), time.Now())
+			if err != nil {
+				t.Fatalf(`Could not write entry to '%s': %v`, archiveName, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf(`Could not close '%s': %v`, archiveName, err)
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf(`Second close of '%s' failed: %v`, archiveName, err)
+		}
+
+		checkEntries(t, archivePath)
+	}
+}
+
+func TestReadEntry(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), `test.tar`)
+	writeTestTar(t, archivePath, false, nil)
+
+	content, err := ReadEntry(archivePath, `dir/b.txt`, 100)
+	if err != nil {
+		t.Fatalf(`Could not read entry: %v`, err)
+	}
+
+	if string(content) != testEntries[`dir/b.txt`] {
+		t.Fatal(`Entry has wrong content`)
+	}
+
+	_, err = ReadEntry(archivePath, `dir/c.bin`, 100)
+	if err == nil {
+		t.Fatal(`Too large entry not detected`)
+	}
+
+	_, err = ReadEntry(archivePath, `d.txt`, 100)
+	if !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf(`Missing entry not detected: %v`, err)
+	}
+}
+
+// ******** Private functions ********
+
+// checkEntries checks that the archive contains exactly the test entries.
+func checkEntries(t *testing.T, archivePath string) {
+	found := make(map[string]string)
+	err := ForEachEntry(archivePath, func(entryPath string, r io.Reader) error {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		found[entryPath] = string(content)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf(`Error reading archive: %v`, err)
+	}
+
+	if len(found) != len(testEntries) {
+		t.Fatalf(`Archive has %d entries instead of %d`, len(found), len(testEntries))
+	}
+
+	for entryPath, content := range testEntries {
+		if found[entryPath] != content {
+			t.Fatalf(`Entry '%s' has wrong content`, entryPath)
+		}
+	}
+}
+
+// writeTestZip writes the test entries to a zip archive with the given prefix and a directory entry.
+func writeTestZip(t *testing.T, archivePath string, prefix string) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf(`Could not create archive: %v`, err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+
+	_, err = zw.Create(`dir/`)
+	if err != nil {
+		t.Fatalf(`Could not create directory entry: %v`, err)
+	}
+
+	var w io.Writer
+	for entryPath, content := range testEntries {
+		w, err = zw.Create(prefix + entryPath)
+		if err != nil {
+			t.Fatalf(`Could not create entry: %v`, err)
+		}
+
+		_, _ = w.Write([]byte(content))
+	}
+
+	err = zw.Close()
+	if err != nil {
+		t.Fatalf(`Could not close archive: %v`, err)
+	}
+}
+
+// writeTestTar writes the test entries and an optional extra entry to a tar archive.
+func writeTestTar(t *testing.T, archivePath string, isCompressed bool, extraHeader *tar.Header) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf(`Could not create archive: %v`, err)
+	}
+	defer f.Close()
+
+	var w io.Writer = f
+	var gw *gzip.Writer
+	if isCompressed {
+		gw = gzip.NewWriter(f)
+		w = gw
+	}
+
+	tw := tar.NewWriter(w)
+
+	_ = tw.WriteHeader(&tar.Header{Name: `dir/`, Typeflag: tar.TypeDir, Mode: 0755})
+
+	for entryPath, content := range testEntries {
+		err = tw.WriteHeader(&tar.Header{Name: entryPath, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))})
+		if err != nil {
+			t.Fatalf(`Could not write header: %v`, err)
+		}
+
+		_, _ = tw.Write([]byte(content))
+	}
+
+	if extraHeader != nil {
+		_ = tw.WriteHeader(extraHeader)
+	}
+
+	err = tw.Close()
+	if err != nil {
+		t.Fatalf(`Could not close archive: %v`, err)
+	}
+
+	if gw != nil {
+		_ = gw.Close()
+	}
+}
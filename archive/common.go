@@ -0,0 +1,92 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+// Package archive implements the handling of zip and tar archives.
+package archive
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ******** Private types ********
+
+// archiveType is the type of archive.
+type archiveType byte
+
+// ******** Private constants ********
+
+// These are the possible values for archiveType.
+const (
+	archiveTypeInvalid archiveType = iota
+	archiveTypeZip
+	archiveTypeTar
+	archiveTypeTarGz
+)
+
+// ******** Private functions ********
+
+// archiveTypeFromName determines the archive type from the file name extension.
+func archiveTypeFromName(archivePath string) (archiveType, error) {
+	lowerPath := strings.ToLower(archivePath)
+
+	switch {
+	case strings.HasSuffix(lowerPath, `.zip`):
+		return archiveTypeZip, nil
+
+	case strings.HasSuffix(lowerPath, `.tar`):
+		return archiveTypeTar, nil
+
+	case strings.HasSuffix(lowerPath, `.tar.gz`),
+		strings.HasSuffix(lowerPath, `.tgz`):
+		return archiveTypeTarGz, nil
+
+	default:
+		return archiveTypeInvalid, fmt.Errorf(`Unknown archive type of file '%s' (must be '.zip', '.tar', '.tar.gz' or '.tgz')`, archivePath)
+	}
+}
+
+// normalizedEntryPath returns the cleaned path of an archive entry.
+// Entry paths must be relative and must not point outside the archive.
+func normalizedEntryPath(entryPath string) (string, error) {
+	if len(entryPath) == 0 {
+		return ``, fmt.Errorf(`Archive contains an entry with an empty name`)
+	}
+
+	if path.IsAbs(entryPath) {
+		return ``, fmt.Errorf(`Archive entry '%s' has an absolute path`, entryPath)
+	}
+
+	result := path.Clean(entryPath)
+	if result == `..` || strings.HasPrefix(result, `../`) {
+		return ``, fmt.Errorf(`Archive entry '%s' points outside of the archive`, entryPath)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,224 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Read single entries.
+//
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"filesigner/filehelper"
+	"filesigner/set"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ******** Public variables ********
+
+// ErrEntryNotFound is returned when an entry is not present in an archive.
+var ErrEntryNotFound = errors.New(`Entry not found in archive`)
+
+// ******** Public types ********
+
+// EntryFunc is called for each regular file in an archive.
+// The entry path always uses slashes as separators.
+// The reader is only valid for the duration of the call.
+type EntryFunc func(entryPath string, r io.Reader) error
+
+// ******** Public functions ********
+
+// ForEachEntry calls fn for each regular file in the archive with the given path.
+// The archive type is determined from the file name extension.
+// Directory entries are skipped. All other entry types, entries with
+// invalid paths and entries that occur more than once are errors.
+func ForEachEntry(archivePath string, fn EntryFunc) error {
+	aType, err := archiveTypeFromName(archivePath)
+	if err != nil {
+		return err
+	}
+
+	seenPaths := set.New[string]()
+	checkedFn := func(entryPath string, r io.Reader) error {
+		normalizedPath, pathErr := normalizedEntryPath(entryPath)
+		if pathErr != nil {
+			return pathErr
+		}
+
+		if seenPaths.Contains(normalizedPath) {
+			return fmt.Errorf(`Archive contains entry '%s' more than once`, normalizedPath)
+		}
+		seenPaths.Add(normalizedPath)
+
+		return fn(normalizedPath, r)
+	}
+
+	if aType == archiveTypeZip {
+		return forEachZipEntry(archivePath, checkedFn)
+	}
+
+	return forEachTarEntry(archivePath, aType == archiveTypeTarGz, checkedFn)
+}
+
+// ReadEntry reads the content of the entry with the given path from an archive.
+// The entry must not be larger than maxSize bytes.
+// If there is no such entry in the archive ErrEntryNotFound is returned.
+func ReadEntry(archivePath string, entryPath string, maxSize int64) ([]byte, error) {
+	var result []byte
+	isFound := false
+
+	err := ForEachEntry(archivePath, func(aPath string, r io.Reader) error {
+		if aPath != entryPath {
+			return nil
+		}
+
+		var err error
+		result, err = io.ReadAll(io.LimitReader(r, maxSize+1))
+		if err != nil {
+			return err
+		}
+
+		if int64(len(result)) > maxSize {
+			return fmt.Errorf(`Archive entry '%s' is too large`, entryPath)
+		}
+
+		isFound = true
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !isFound {
+		return nil, ErrEntryNotFound
+	}
+
+	return result, nil
+}
+
+// ******** Private functions ********
+
+// forEachZipEntry calls fn for each regular file in a zip archive.
+func forEachZipEntry(archivePath string, fn EntryFunc) error {
+	zipReader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer closeReader(zipReader)
+
+	for _, zipFile := range zipReader.File {
+		mode := zipFile.Mode()
+		if mode.IsDir() {
+			continue
+		}
+
+		if !mode.IsRegular() {
+			return makeNotRegularError(zipFile.Name)
+		}
+
+		err = callWithZipEntry(zipFile, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// callWithZipEntry opens a zip entry and calls fn with it.
+func callWithZipEntry(zipFile *zip.File, fn EntryFunc) error {
+	entryReader, err := zipFile.Open()
+	if err != nil {
+		return err
+	}
+	defer closeReader(entryReader)
+
+	return fn(zipFile.Name, entryReader)
+}
+
+// forEachTarEntry calls fn for each regular file in a tar archive, which may be gzip-compressed.
+func forEachTarEntry(archivePath string, isCompressed bool, fn EntryFunc) error {
+	archiveFile, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer filehelper.CloseFile(archiveFile)
+
+	var archiveReader io.Reader = archiveFile
+	if isCompressed {
+		var gzipReader *gzip.Reader
+		gzipReader, err = gzip.NewReader(archiveFile)
+		if err != nil {
+			return err
+		}
+		defer closeReader(gzipReader)
+
+		archiveReader = gzipReader
+	}
+
+	tarReader := tar.NewReader(archiveReader)
+
+	var header *tar.Header
+	for {
+		header, err = tarReader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			continue
+
+		case tar.TypeReg:
+			err = fn(header.Name, tarReader)
+			if err != nil {
+				return err
+			}
+
+		default:
+			return makeNotRegularError(header.Name)
+		}
+	}
+}
+
+// makeNotRegularError builds the error for an entry that is not a regular file.
+func makeNotRegularError(entryPath string) error {
+	return fmt.Errorf(`Archive entry '%s' is not a regular file`, entryPath)
+}
+
+// closeReader closes a reader. There is nothing sensible to do with an error here.
+func closeReader(r io.Closer) {
+	_ = r.Close()
+}
@@ -0,0 +1,176 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// ******** Public types ********
+
+// Writer writes entries into a zip or tar archive.
+type Writer struct {
+	file       *os.File
+	zipWriter  *zip.Writer
+	gzipWriter *gzip.Writer
+	tarWriter  *tar.Writer
+}
+
+// ******** Private constants ********
+
+// manifestFileMode is the file mode of entries that are written from memory.
+const manifestFileMode fs.FileMode = 0644
+
+// ******** Type creation ********
+
+// NewWriter creates a new archive with the given path.
+// The archive type is determined from the file name extension.
+func NewWriter(archivePath string) (*Writer, error) {
+	aType, err := archiveTypeFromName(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Writer{}
+	result.file, err = os.Create(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch aType {
+	case archiveTypeZip:
+		result.zipWriter = zip.NewWriter(result.file)
+
+	case archiveTypeTarGz:
+		result.gzipWriter = gzip.NewWriter(result.file)
+		result.tarWriter = tar.NewWriter(result.gzipWriter)
+
+	default:
+		result.tarWriter = tar.NewWriter(result.file)
+	}
+
+	return result, nil
+}
+
+// ******** Public functions ********
+
+// CreateEntry creates an entry for a file with the given file info.
+// The content of the file has to be written to the returned writer before the next entry is created.
+// For tar archives exactly the number of bytes given by the size in the file info must be written.
+func (w *Writer) CreateEntry(entryPath string, fileInfo fs.FileInfo) (io.Writer, error) {
+	if w.zipWriter != nil {
+		header, err := zip.FileInfoHeader(fileInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		header.Name = entryPath
+		header.Method = zip.Deflate
+
+		return w.zipWriter.CreateHeader(header)
+	}
+
+	header, err := tar.FileInfoHeader(fileInfo, ``)
+	if err != nil {
+		return nil, err
+	}
+
+	header.Name = entryPath
+
+	err = w.tarWriter.WriteHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.tarWriter, nil
+}
+
+// WriteEntry writes an entry with the given content.
+func (w *Writer) WriteEntry(entryPath string, content []byte, modTime time.Time) error {
+	var entryWriter io.Writer
+	var err error
+
+	if w.zipWriter != nil {
+		header := &zip.FileHeader{
+			Name:     entryPath,
+			Method:   zip.Deflate,
+			Modified: modTime,
+		}
+		header.SetMode(manifestFileMode)
+
+		entryWriter, err = w.zipWriter.CreateHeader(header)
+	} else {
+		err = w.tarWriter.WriteHeader(&tar.Header{
+			Name:     entryPath,
+			Typeflag: tar.TypeReg,
+			Mode:     int64(manifestFileMode),
+			Size:     int64(len(content)),
+			ModTime:  modTime,
+		})
+		entryWriter = w.tarWriter
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = entryWriter.Write(content)
+
+	return err
+}
+
+// Close finishes the archive and closes the archive file.
+// Calling Close on a closed writer does nothing.
+func (w *Writer) Close() error {
+	if w.file == nil {
+		return nil
+	}
+
+	var archiveErr error
+	if w.zipWriter != nil {
+		archiveErr = w.zipWriter.Close()
+	} else {
+		archiveErr = w.tarWriter.Close()
+		if w.gzipWriter != nil {
+			archiveErr = errors.Join(archiveErr, w.gzipWriter.Close())
+		}
+	}
+
+	fileErr := w.file.Close()
+	w.file = nil
+
+	return errors.Join(archiveErr, fileErr)
+}
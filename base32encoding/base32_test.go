@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,11 +20,13 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.1.0
+// Version: 1.3.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
 //    2024-03-23: V1.1.0: Added tests for all functions.
+//    2026-10-19: V1.2.0: Added tests for keys with check code.
+//    2026-10-19: V1.3.0: Added test for two typos that look like one.
 //
 
 package base32encoding
@@ -32,7 +34,9 @@ package base32encoding
 import (
 	"bytes"
 	cryptorand "crypto/rand"
+	"errors"
 	"math/rand"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -85,6 +89,123 @@ func TestDecodeKeyInvalidGroupSize(t *testing.T) {
 	}
 }
 
+func TestEncodeKeyWithCheck(t *testing.T) {
+	for range testLoopCount {
+		sl := rand.Intn(18) + 1
+		s := make([]byte, sl)
+		_, _ = cryptorand.Read(s)
+		es := EncodeKeyWithCheck(s)
+		ds, err := DecodeKeyWithCheck(es)
+		if err != nil {
+			t.Fatalf(`error decoding key '%s': %v`, es, err)
+		}
+
+		if !bytes.Equal(s, ds) {
+			t.Fatalf(`decoding '%s' did not result in '%x', but '%x'`, es, s, ds)
+		}
+	}
+}
+
+func TestDecodeKeyWithCheckTypo(t *testing.T) {
+	s := make([]byte, 16)
+	for range testLoopCount {
+		_, _ = cryptorand.Read(s)
+		es := []byte(EncodeKeyWithCheck(s))
+
+		// Replace one character that is not a separator with a different character.
+		i := rand.Intn(len(es))
+		for es[i] == keySeparator {
+			i = rand.Intn(len(es))
+		}
+		es[i] = otherKeyCharacter(es[i])
+
+		_, err := DecodeKeyWithCheck(string(es))
+		var typoErr *KeyTypoError
+		if !errors.As(err, &typoErr) {
+			t.Fatalf(`typo in '%s' not detected: %v`, es, err)
+		}
+
+		expectedGroup := i/(keyGroupSize+1) + 1
+		if typoErr.Group != expectedGroup {
+			t.Fatalf(`typo in '%s' reported in group %d instead of %d`, es, typoErr.Group, expectedGroup)
+		}
+
+		if !typoErr.IsGuess {
+			t.Fatalf(`typo in '%s' located with check code is not reported as a guess`, es)
+		}
+	}
+}
+
+func TestDecodeKeyWithCheckTwoTyposLookLikeOne(t *testing.T) {
+	es := []byte(EncodeKeyWithCheck(make([]byte, 16)))
+
+	// Two typos in the first group can have the check values of a single typo in another group.
+	for _, c0 := range []byte(keyAlphabet) {
+		for _, c1 := range []byte(keyAlphabet) {
+			if c0 == es[0] || c1 == es[1] {
+				continue
+			}
+
+			ts := slices.Clone(es)
+			ts[0] = c0
+			ts[1] = c1
+
+			_, err := DecodeKeyWithCheck(string(ts))
+			if err == nil {
+				t.Fatalf(`typos in '%s' not detected`, ts)
+			}
+
+			var typoErr *KeyTypoError
+			if errors.As(err, &typoErr) && typoErr.Group != 1 {
+				if !typoErr.IsGuess || !strings.Contains(typoErr.Error(), `probably`) {
+					t.Fatalf(`group %d of typos in '%s' is not reported as a guess: %v`, typoErr.Group, ts, err)
+				}
+
+				return
+			}
+		}
+	}
+
+	t.Fatal(`no two typos found that look like a single typo in another group`)
+}
+
+func TestDecodeKeyWithCheckTypos(t *testing.T) {
+	s := make([]byte, 16)
+	for range testLoopCount {
+		_, _ = cryptorand.Read(s)
+		es := []byte(EncodeKeyWithCheck(s))
+
+		// Replace two characters that are not separators with different characters.
+		positions := rand.Perm(len(es))
+		changeCount := 0
+		for _, i := range positions {
+			if es[i] != keySeparator {
+				es[i] = otherKeyCharacter(es[i])
+				changeCount++
+				if changeCount == 2 {
+					break
+				}
+			}
+		}
+
+		_, err := DecodeKeyWithCheck(string(es))
+		if err == nil {
+			t.Fatalf(`typos in '%s' not detected`, es)
+		}
+	}
+}
+
+func TestDecodeKeyWithCheckInvalidCharacter(t *testing.T) {
+	es := []byte(EncodeKeyWithCheck(make([]byte, 16)))
+	es[11] = 'A'
+
+	_, err := DecodeKeyWithCheck(string(es))
+	var typoErr *KeyTypoError
+	if !errors.As(err, &typoErr) || typoErr.Group != 3 || typoErr.IsGuess {
+		t.Fatalf(`invalid character in '%s' not reported in group 3: %v`, es, err)
+	}
+}
+
 func TestEncodeToString(t *testing.T) {
 	for range testLoopCount {
 		sl := rand.Intn(30)
@@ -131,6 +252,16 @@ func TestEncodeToBytes(t *testing.T) {
 	}
 }
 
+// otherKeyCharacter returns a random key character that is different from the given one.
+func otherKeyCharacter(c byte) byte {
+	for {
+		result := keyAlphabet[rand.Intn(len(keyAlphabet))]
+		if result != c {
+			return result
+		}
+	}
+}
+
 func BenchmarkEncodeKey(b *testing.B) {
 	source := make([]byte, 16)
 	_, _ = cryptorand.Read(source)
@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,10 +20,11 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.1.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Add alphabet constant for keys.
 //
 
 // Package base32encoding implements the base32 encodings used in this program.
@@ -38,10 +39,13 @@ import (
 const keySeparator = '-'
 const keyGroupSize = 4
 
+// keyAlphabet is the custom alphabet with only upper-case letters and digits that is used to encode keys.
+const keyAlphabet = `B9C8D7E6F5G4H3J2K1L0MNPQRSTVWXYZ`
+
 // ******** Private variables ********
 
 // enc is a base32 encoder that uses the word-safe alphabet.
 var enc = base32.NewEncoding(`3479BCDFGHJLMRQSTVZbcdfghjmrstvz`).WithPadding(base32.NoPadding)
 
 // encKey is a base32 encoder which uses a custom alphabet with only upper-case letters and digits to encode keys.
-var encKey = base32.NewEncoding(`B9C8D7E6F5G4H3J2K1L0MNPQRSTVWXYZ`).WithPadding(base32.NoPadding)
+var encKey = base32.NewEncoding(keyAlphabet).WithPadding(base32.NoPadding)
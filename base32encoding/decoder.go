@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,10 +20,11 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.1.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Separate removal of key separators.
 //
 
 package base32encoding
@@ -50,12 +51,23 @@ func DecodeFromBytes(b []byte) ([]byte, error) {
 
 // DecodeKey decodes a key.
 func DecodeKey(keyId string) ([]byte, error) {
+	encodedKey, err := removeKeySeparators(keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	return encKey.DecodeString(encodedKey)
+}
+
+// ******** Private functions ********
+
+// removeKeySeparators removes the separators from a key and checks the group sizes.
+func removeKeySeparators(keyId string) (string, error) {
 	keyIdLength := len(keyId)
 	result := make([]byte, keyIdLength)
 
 	destinationIndex := 0
 	separatorPosition := 0
-	separatorCount := 0
 	for {
 		separatorPosition = strings.IndexByte(keyId, keySeparator)
 		if separatorPosition < 0 {
@@ -65,9 +77,8 @@ func DecodeKey(keyId string) ([]byte, error) {
 		}
 
 		if separatorPosition != keyGroupSize {
-			return nil, errors.New(`Invalid group size in key id`)
+			return ``, errors.New(`Invalid group size in key id`)
 		}
-		separatorCount++
 		copy(result[destinationIndex:], keyId[:separatorPosition])
 		destinationIndex += separatorPosition
 		separatorPosition++
@@ -75,5 +86,5 @@ func DecodeKey(keyId string) ([]byte, error) {
 		keyIdLength -= separatorPosition
 	}
 
-	return encKey.DecodeString(string(result[:destinationIndex]))
+	return string(result[:destinationIndex]), nil
 }
@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,10 +20,11 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.1.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Separate grouping of encoded keys.
 //
 
 package base32encoding
@@ -45,7 +46,13 @@ func EncodeToBytes(b []byte) []byte {
 
 // EncodeKey encodes a key with groups of letters and numbers.
 func EncodeKey(key []byte) string {
-	encodedKey := encKey.EncodeToString(key)
+	return groupKey(encKey.EncodeToString(key))
+}
+
+// ******** Private functions ********
+
+// groupKey separates an encoded key into groups.
+func groupKey(encodedKey string) string {
 	encodedKeyLength := len(encodedKey)
 	if encodedKeyLength == 0 {
 		return ``
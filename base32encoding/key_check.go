@@ -0,0 +1,219 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Report the group of a typo that has been located with the check code as a guess.
+//
+
+package base32encoding
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The check code of a key is a Reed-Solomon code over GF(32) with two check symbols.
+// Each character of an encoded key is one symbol of the code.
+// The code has a minimum distance of 3, so one or two wrong symbols are always detected.
+// Three or more wrong symbols are only detected with a high probability.
+//
+// A single wrong symbol can be located, so the group with a typo can be reported.
+// However, two or more wrong symbols often result in the same check values as a single
+// wrong symbol in another group. So the check code can not tell if there is one wrong symbol
+// and the located group is only a guess.
+
+// ******** Public types ********
+
+// KeyTypoError is returned if a key with check code contains a wrong character that can be located.
+type KeyTypoError struct {
+	// Group is the number of the group with the typo, starting at 1.
+	Group int
+	// IsGuess is true, if the group has been located with the check code.
+	// Then it is only correct, if there is a single typo.
+	IsGuess bool
+}
+
+// Error returns the error text.
+func (e *KeyTypoError) Error() string {
+	if e.IsGuess {
+		return fmt.Sprintf(`Key has a typo, probably in group %d`, e.Group)
+	}
+
+	return fmt.Sprintf(`Key has a typo in group %d`, e.Group)
+}
+
+// ******** Public variables ********
+
+// ErrKeyTypos is returned if a key with check code contains more than one wrong character
+// and this can be seen from the check code.
+var ErrKeyTypos = errors.New(`Key has more than one typo`)
+
+// ******** Private constants ********
+
+// keyCheckSize is the number of check symbols of a key.
+const keyCheckSize = 2
+
+// gfSize is the number of elements of GF(32).
+const gfSize = 32
+
+// gfOrder is the order of the multiplicative group of GF(32).
+const gfOrder = gfSize - 1
+
+// gfPolynomial is the primitive polynomial x^5 + x^2 + 1 that defines GF(32).
+const gfPolynomial = 0b100101
+
+// ******** Private variables ********
+
+// gfExp contains the powers of the primitive element of GF(32).
+// It has twice the necessary length, so products of powers need no modulo operation.
+var gfExp [2 * gfOrder]byte
+
+// gfLog contains the logarithms of the elements of GF(32) to the base of the primitive element.
+var gfLog [gfSize]int
+
+// ******** Initialization ********
+
+// init builds the logarithm tables of GF(32).
+func init() {
+	value := 1
+	for i := range gfOrder {
+		gfExp[i] = byte(value)
+		gfExp[i+gfOrder] = byte(value)
+		gfLog[value] = i
+
+		value <<= 1
+		if value >= gfSize {
+			value ^= gfPolynomial
+		}
+	}
+}
+
+// ******** Public functions ********
+
+// EncodeKeyWithCheck encodes a key with groups of letters and numbers and appends a check code.
+// The key must not be longer than 18 bytes.
+func EncodeKeyWithCheck(key []byte) string {
+	encodedKey := encKey.EncodeToString(key)
+	if len(encodedKey) == 0 {
+		return ``
+	}
+
+	symbols := make([]byte, len(encodedKey))
+	for i := range len(encodedKey) {
+		symbols[i] = byte(strings.IndexByte(keyAlphabet, encodedKey[i]))
+	}
+
+	// The check symbols are the coefficients of x^1 and x^0 and have to be chosen so that
+	// the code polynomial has the roots a^1 and a^2, with a being the primitive element.
+	// This results in two linear equations for the two check symbols.
+	d1 := gfMul(evaluate(symbols, gfExp[1]), gfExp[2])
+	d2 := gfMul(evaluate(symbols, gfExp[2]), gfExp[4])
+	c1 := gfDiv(d1^d2, gfExp[1]^gfExp[2])
+	c0 := d1 ^ gfMul(c1, gfExp[1])
+
+	return groupKey(encodedKey + string(keyAlphabet[c1]) + string(keyAlphabet[c0]))
+}
+
+// DecodeKeyWithCheck decodes a key that has been encoded with EncodeKeyWithCheck.
+// If the key contains an invalid character or the check code points to a single typo a KeyTypoError is returned.
+// If the check code shows that it contains more than one typo ErrKeyTypos is returned.
+func DecodeKeyWithCheck(keyId string) ([]byte, error) {
+	encodedKey, err := removeKeySeparators(keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	codeLength := len(encodedKey)
+	if codeLength <= keyCheckSize || codeLength > gfOrder {
+		return nil, errors.New(`Key has invalid length`)
+	}
+
+	symbols := make([]byte, codeLength)
+	for i := range codeLength {
+		symbolIndex := strings.IndexByte(keyAlphabet, encodedKey[i])
+		if symbolIndex < 0 {
+			return nil, &KeyTypoError{Group: i/keyGroupSize + 1}
+		}
+
+		symbols[i] = byte(symbolIndex)
+	}
+
+	s1 := evaluate(symbols, gfExp[1])
+	s2 := evaluate(symbols, gfExp[2])
+	if s1 != 0 || s2 != 0 {
+		return nil, locateTypo(s1, s2, codeLength)
+	}
+
+	return encKey.DecodeString(encodedKey[:codeLength-keyCheckSize])
+}
+
+// ******** Private functions ********
+
+// locateTypo returns the error for the syndromes of a code with a single or multiple wrong symbols.
+// The located group is a guess, as multiple wrong symbols may have the syndromes of a single wrong symbol.
+func locateTypo(s1 byte, s2 byte, codeLength int) error {
+	// A single wrong symbol at the coefficient of x^p results in the syndromes s1 = e*a^p and s2 = e*a^(2p).
+	// Syndromes that do not have this form can not come from a single wrong symbol.
+	if s1 == 0 || s2 == 0 {
+		return ErrKeyTypos
+	}
+
+	p := gfLog[gfDiv(s2, s1)]
+	if p >= codeLength {
+		return ErrKeyTypos
+	}
+
+	return &KeyTypoError{Group: (codeLength-1-p)/keyGroupSize + 1, IsGuess: true}
+}
+
+// evaluate evaluates the polynomial with the symbols as coefficients at x.
+// The first symbol is the coefficient of the highest power.
+func evaluate(symbols []byte, x byte) byte {
+	var result byte
+	for _, symbol := range symbols {
+		result = gfMul(result, x) ^ symbol
+	}
+
+	return result
+}
+
+// gfMul multiplies two elements of GF(32).
+func gfMul(a byte, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return gfExp[gfLog[a]+gfLog[b]]
+}
+
+// gfDiv divides two elements of GF(32). The divisor must not be 0.
+func gfDiv(a byte, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+
+	return gfExp[gfLog[a]+gfOrder-gfLog[b]]
+}
@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,10 +20,13 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.3.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Add size parsing.
+//    2026-10-19: V1.2.0: Add name of data from stdin.
+//    2026-10-19: V1.3.0: Add time and duration parsing.
 //
 
 package cmdline
@@ -31,7 +34,12 @@ package cmdline
 import (
 	"errors"
 	"filesigner/filehelper"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // ******** Private constants ********
@@ -45,6 +53,24 @@ const signaturesFileNameSuffix = `-signatures.json`
 // wildCards contains the valid wild card characters.
 const wildCards = `*?`
 
+// timeFormats contains the formats that are accepted for times on the command line.
+// Times without time zone are local times.
+var timeFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05 Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// durationUnits contains the units that are accepted for durations on the command line.
+var durationUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
 // ******** Private functions ********
 
 // checkSignaturesFileName checks if the supplied file path is only a file name.
@@ -58,3 +84,90 @@ func checkSignaturesFileName(filePath string) error {
 
 	return nil
 }
+
+// parseSize parses a size in bytes with an optional binary unit suffix 'K', 'M' or 'G'.
+func parseSize(sizeText string) (int64, error) {
+	numberText := strings.ToUpper(strings.TrimSpace(sizeText))
+
+	shift := 0
+	if len(numberText) != 0 {
+		switch numberText[len(numberText)-1] {
+		case 'K':
+			shift = 10
+		case 'M':
+			shift = 20
+		case 'G':
+			shift = 30
+		}
+	}
+
+	if shift != 0 {
+		numberText = numberText[:len(numberText)-1]
+	}
+
+	result, err := strconv.ParseInt(numberText, 10, 64)
+	if err != nil || result < 0 || result > (1<<62)>>shift {
+		return 0, fmt.Errorf(`Invalid size: '%s'`, sizeText)
+	}
+
+	return result << shift, nil
+}
+
+// getStdinDataName checks and returns the name under which data from stdin are signed and verified.
+// The name must be a relative path that does not point outside the current directory.
+// It is returned with slashes as separators.
+func getStdinDataName(readStdInData bool, asName string) (string, error) {
+	if !readStdInData {
+		if len(asName) != 0 {
+			return ``, errors.New(`Option 'as' is only valid together with option 'stdin-data'`)
+		}
+
+		return ``, nil
+	}
+
+	if len(asName) == 0 {
+		return ``, errors.New(`Option 'stdin-data' needs a name in option 'as'`)
+	}
+
+	result := path.Clean(filepath.ToSlash(asName))
+	if path.IsAbs(result) || filepath.IsAbs(asName) || result == `.` || result == `..` || strings.HasPrefix(result, `../`) {
+		return ``, fmt.Errorf(`Name '%s' for data from stdin must be a relative path inside the current directory`, asName)
+	}
+
+	return result, nil
+}
+
+// parseTime parses a time in one of the accepted time formats.
+func parseTime(timeText string) (time.Time, error) {
+	trimmedTimeText := strings.TrimSpace(timeText)
+	for _, format := range timeFormats {
+		result, err := time.ParseInLocation(format, trimmedTimeText, time.Local)
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf(`Invalid time: '%s'`, timeText)
+}
+
+// parseDuration parses a positive duration with one of the units 'm', 'h', 'd' or 'w'.
+func parseDuration(durationText string) (time.Duration, error) {
+	numberText := strings.ToLower(strings.TrimSpace(durationText))
+
+	var unit time.Duration
+	var isValid bool
+	if len(numberText) != 0 {
+		unit, isValid = durationUnits[numberText[len(numberText)-1]]
+	}
+
+	if isValid {
+		var count int64
+		var err error
+		count, err = strconv.ParseInt(numberText[:len(numberText)-1], 10, 64)
+		if err == nil && count > 0 && count <= int64((1<<62)/unit) {
+			return time.Duration(count) * unit, nil
+		}
+	}
+
+	return 0, fmt.Errorf(`Invalid duration: '%s'`, durationText)
+}
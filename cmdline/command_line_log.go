@@ -0,0 +1,126 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package cmdline
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/pflag"
+	"os"
+	"strings"
+)
+
+// ******** Public constants ********
+
+// These are the subcommands of the "log" command.
+const (
+	LogSubcommandVerify = `verify`
+)
+
+// ******** Public types ********
+
+// LogCommandLine is the object that contains all the data
+// to interpret a "log" command line.
+type LogCommandLine struct {
+	// Public elements
+	Subcommand     string
+	VerificationId string
+	LogPath        string
+
+	// Private elements
+	fs *pflag.FlagSet
+}
+
+// ******** Public functions ********
+
+// NewLogCommandLine sets up the flag parser for the "log" command.
+func NewLogCommandLine() *LogCommandLine {
+	logCmd := pflag.NewFlagSet(`log`, pflag.ContinueOnError)
+
+	logCmd.SetOutput(os.Stdout)
+
+	result := &LogCommandLine{fs: logCmd}
+
+	logCmd.StringVar(&result.LogPath, `file`, ``, `Path of the signature log`)
+
+	logCmd.SortFlags = true
+
+	return result
+}
+
+// Parse parses the command line according to the flag rules.
+// The first argument is the subcommand.
+func (cl *LogCommandLine) Parse(args []string) (error, bool) {
+	if len(args) == 0 {
+		return errors.New(`Log subcommand is missing`), false
+	}
+
+	cl.Subcommand = strings.ToLower(args[0])
+
+	err := cl.fs.Parse(args[1:])
+	if errors.Is(err, pflag.ErrHelp) {
+		return nil, true
+	}
+
+	return err, false
+}
+
+// PrintUsage prints the usage information for the command.
+func (cl *LogCommandLine) PrintUsage() {
+	cl.fs.PrintDefaults()
+}
+
+// ExtractCommandData extracts the data that are needed for the command from the command line.
+func (cl *LogCommandLine) ExtractCommandData() error {
+	// 1. Check the subcommand and its arguments.
+	if cl.Subcommand != LogSubcommandVerify {
+		return fmt.Errorf(`Unknown log subcommand: '%s'`, cl.Subcommand)
+	}
+
+	switch cl.fs.NArg() {
+	case 0:
+		// The verification id is optional.
+
+	case 1:
+		cl.VerificationId = strings.TrimSpace(cl.fs.Arg(0))
+		if len(cl.VerificationId) == 0 {
+			return errors.New(`Verification id must not be empty`)
+		}
+
+	default:
+		return errors.New(`Too many arguments`)
+	}
+
+	// 2. The signature log has no default path.
+	if len(cl.LogPath) == 0 {
+		return errors.New(`Option 'file' is missing`)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Validity of the revocation list.
+//
+
+package cmdline
+
+import (
+	"errors"
+	"github.com/spf13/pflag"
+	"os"
+	"strings"
+	"time"
+)
+
+// ******** Public types ********
+
+// RevokeCommandLine is the object that contains all the data
+// to interpret a "revoke" command line.
+type RevokeCommandLine struct {
+	// Public elements
+	VerificationId string
+	Reason         string
+	ListPath       string
+	ValidFor       time.Duration
+
+	// Private elements
+	fs           *pflag.FlagSet
+	validForText string
+}
+
+// ******** Private constants ********
+
+// defaultListValidity is the default duration for which a revocation list is valid.
+const defaultListValidity = `30d`
+
+// ******** Public functions ********
+
+// NewRevokeCommandLine sets up the flag parser for the "revoke" command.
+func NewRevokeCommandLine() *RevokeCommandLine {
+	revokeCmd := pflag.NewFlagSet(`revoke`, pflag.ContinueOnError)
+
+	revokeCmd.SetOutput(os.Stdout)
+
+	result := &RevokeCommandLine{fs: revokeCmd}
+
+	revokeCmd.StringVar(&result.Reason, `reason`, ``, `Reason for the revocation`)
+
+	revokeCmd.StringVar(&result.ListPath, `list`, ``, `Path of the revocation list`)
+
+	revokeCmd.StringVar(&result.validForText, `valid-for`, defaultListValidity, `Duration for which the revocation list is valid (with unit 'm', 'h', 'd' or 'w')`)
+
+	revokeCmd.SortFlags = true
+
+	return result
+}
+
+// Parse parses the command line according to the flag rules.
+func (cl *RevokeCommandLine) Parse(args []string) (error, bool) {
+	err := cl.fs.Parse(args)
+	if errors.Is(err, pflag.ErrHelp) {
+		return nil, true
+	}
+
+	return err, false
+}
+
+// PrintUsage prints the usage information for the command.
+func (cl *RevokeCommandLine) PrintUsage() {
+	cl.fs.PrintDefaults()
+}
+
+// ExtractCommandData extracts the data that are needed for the command from the command line.
+func (cl *RevokeCommandLine) ExtractCommandData() error {
+	// 1. Get the verification id. Without a verification id the list is only signed again.
+	switch cl.fs.NArg() {
+	case 0:
+		if cl.fs.Changed(`reason`) {
+			return errors.New(`Option 'reason' must not be specified without a verification id`)
+		}
+
+	case 1:
+		cl.VerificationId = strings.TrimSpace(cl.fs.Arg(0))
+		if len(cl.VerificationId) == 0 {
+			return errors.New(`Verification id must not be empty`)
+		}
+
+		if len(strings.TrimSpace(cl.Reason)) == 0 {
+			return errors.New(`Option 'reason' is missing`)
+		}
+
+	default:
+		return errors.New(`Too many arguments`)
+	}
+
+	// 2. Check the options.
+	if len(cl.ListPath) == 0 {
+		return errors.New(`Option 'list' is missing`)
+	}
+
+	var err error
+	cl.ValidFor, err = parseDuration(cl.validForText)
+
+	return err
+}
+
+// IsRefresh returns true, if the revocation list is only signed again.
+func (cl *RevokeCommandLine) IsRefresh() bool {
+	return len(cl.VerificationId) == 0
+}
@@ -0,0 +1,109 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package cmdline
+
+import (
+	"errors"
+	"github.com/spf13/pflag"
+	"os"
+	"strings"
+)
+
+// ******** Public types ********
+
+// SelfCheckCommandLine is the object that contains all the data
+// to interpret a "selfcheck" command line.
+type SelfCheckCommandLine struct {
+	// Public elements
+	VerificationId string
+	SignaturesPath string
+	IdUrl          string
+
+	// Private elements
+	fs *pflag.FlagSet
+}
+
+// ******** Public functions ********
+
+// NewSelfCheckCommandLine sets up the flag parser for the "selfcheck" command.
+func NewSelfCheckCommandLine() *SelfCheckCommandLine {
+	selfCheckCmd := pflag.NewFlagSet(`selfcheck`, pflag.ContinueOnError)
+
+	selfCheckCmd.SetOutput(os.Stdout)
+
+	result := &SelfCheckCommandLine{fs: selfCheckCmd}
+
+	selfCheckCmd.StringVar(&result.SignaturesPath, `signatures`, ``, `Path of the signatures file of the executable (default is the published signatures file next to the executable)`)
+
+	selfCheckCmd.StringVar(&result.IdUrl, `id-url`, ``, `'https' or 'file' URL of a list of context ids and verification ids`)
+
+	selfCheckCmd.SortFlags = true
+
+	return result
+}
+
+// Parse parses the command line according to the flag rules.
+func (cl *SelfCheckCommandLine) Parse(args []string) (error, bool) {
+	err := cl.fs.Parse(args)
+	if errors.Is(err, pflag.ErrHelp) {
+		return nil, true
+	}
+
+	return err, false
+}
+
+// PrintUsage prints the usage information for the command.
+func (cl *SelfCheckCommandLine) PrintUsage() {
+	cl.fs.PrintDefaults()
+}
+
+// ExtractCommandData extracts the data that are needed for the command from the command line.
+func (cl *SelfCheckCommandLine) ExtractCommandData() error {
+	// 1. Get the optional verification id.
+	switch cl.fs.NArg() {
+	case 0:
+		// The verification id may come from an id list.
+
+	case 1:
+		cl.VerificationId = strings.TrimSpace(cl.fs.Arg(0))
+		if len(cl.VerificationId) == 0 {
+			return errors.New(`Verification id must not be empty`)
+		}
+
+	default:
+		return errors.New(`Too many arguments`)
+	}
+
+	// 2. There may only be one source for the verification id.
+	if len(cl.VerificationId) != 0 && len(cl.IdUrl) != 0 {
+		return errors.New(`Verification id must not be specified together with option 'id-url'`)
+	}
+
+	return nil
+}
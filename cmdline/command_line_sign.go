@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,19 +20,37 @@
 //
 // Author: Frank Schwab
 //
-// Version: 2.0.0
+// Version: 2.14.2
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
 //    2024-02-07: V2.0.0: Make an object.
 //    2024-04-05: V2.0.1: Make Stdout the output destination for usage messages.
+//    2026-10-19: V2.1.0: Add archive option.
+//    2026-10-19: V2.2.0: Add into-archive option.
+//    2026-10-19: V2.3.0: Add Merkle tree options.
+//    2026-10-19: V2.4.0: Add stdin-data option.
+//    2026-10-19: V2.5.0: Add words option.
+//    2026-10-19: V2.6.0: Add log option.
+//    2026-10-19: V2.7.0: Add expiry options.
+//    2026-10-19: V2.8.0: Add with-metadata option.
+//    2026-10-19: V2.9.0: Add symlinks option.
+//    2026-10-19: V2.10.0: Add respect-gitignore option.
+//    2026-10-19: V2.11.0: Allow path patterns in include and exclude options.
+//    2026-10-19: V2.12.0: Read defaults from configuration file.
+//    2026-10-19: V2.13.0: Sign ignore files.
+//    2026-10-19: V2.14.0: Add null option.
+//    2026-10-19: V2.14.1: Only use file selection options from the configuration file, if files are selected.
+//    2026-10-19: V2.14.2: Remove superfluous removal of carriage returns.
 //
 
 package cmdline
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
+	"filesigner/filehasher"
 	"filesigner/filehelper"
 	"filesigner/flaglist"
 	"filesigner/set"
@@ -42,6 +60,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // ******** Private constants ********
@@ -67,17 +86,38 @@ type SignCommandLine struct {
 	// Public elements
 	FileList           []string
 	SignaturesFileName string
+	ArchivePath        string
+	IntoArchivePath    string
+	StdinDataName      string
+	LogPath            string
+	ConfigPath         string
 	SignatureType      signaturehandler.SignatureType
+	SymlinkMode        signaturehandler.SymlinkMode
+	ChunkSize          int64
+	NotAfter           time.Time
 	BeQuiet            bool
+	PrintWords         bool
+	WithMetadata       bool
 
 	// Private elements
 	fs                *pflag.FlagSet
+	configPath        string
+	config            *signConfig
 	signatureTypeText string
+	symlinksText      string
+	chunkSizeText     string
+	validForText      string
+	notAfterText      string
+	useMerkle         bool
 	prefix            string
 	fromFileName      string
 	beQuiet           bool
 	doRecursion       bool
+	respectGitignore  bool
 	readStdIn         bool
+	readStdInData     bool
+	nullSeparated     bool
+	asName            string
 	excludeFileList   *flaglist.FileSystemFlagList
 	excludeDirList    *flaglist.FileSystemFlagList
 	includeFileList   *flaglist.FileSystemFlagList
@@ -102,8 +142,16 @@ func NewSignCommandLine() *SignCommandLine {
 
 	signCmd.BoolVarP(&result.doRecursion, `recurse`, `r`, false, `Search this directory and all subdirectories`)
 
+	signCmd.BoolVar(&result.respectGitignore, `respect-gitignore`, false, `Skip files and directories that are ignored by .gitignore files when scanning directories`)
+
 	signCmd.BoolVarP(&result.readStdIn, `stdin`, `s`, false, `Read list of files from stdin`)
 
+	signCmd.BoolVarP(&result.nullSeparated, `null`, `0`, false, `File names in the list from file or stdin are separated by NUL characters instead of line ends`)
+
+	signCmd.BoolVar(&result.readStdInData, `stdin-data`, false, `Sign the data read from stdin`)
+
+	signCmd.StringVar(&result.asName, `as`, ``, `Name under which the data from stdin are signed`)
+
 	result.excludeFileList = flaglist.NewFileSystemFlagList()
 	signCmd.VarP(result.excludeFileList, `exclude-file`, `x`, `Name of file to exclude from signing (may contain wildcards).`)
 
@@ -118,6 +166,28 @@ func NewSignCommandLine() *SignCommandLine {
 
 	signCmd.BoolVarP(&result.BeQuiet, `quiet`, `q`, false, `Print only errors`)
 
+	signCmd.StringVar(&result.ArchivePath, `archive`, ``, `Name of a zip or tar archive whose entries are signed`)
+
+	signCmd.BoolVar(&result.useMerkle, `merkle`, false, `Hash files in chunks that are combined in a Merkle tree`)
+
+	signCmd.StringVar(&result.chunkSizeText, `chunk-size`, `4M`, `Chunk size of the Merkle tree (with optional unit 'K', 'M' or 'G')`)
+
+	signCmd.StringVar(&result.IntoArchivePath, `into-archive`, ``, `Name of a zip or tar archive that the signed files and the signatures file are written to`)
+
+	signCmd.BoolVar(&result.PrintWords, `words`, false, `Print the verification id in word form in quiet mode`)
+
+	signCmd.StringVar(&result.validForText, `valid-for`, ``, `Duration for which the signatures are valid (with unit 'm', 'h', 'd' or 'w')`)
+
+	signCmd.StringVar(&result.notAfterText, `not-after`, ``, `Time after which the signatures are no longer valid`)
+
+	signCmd.StringVar(&result.symlinksText, `symlinks`, `follow`, `Treatment of symbolic links (either 'follow', 'record' or 'reject')`)
+
+	signCmd.BoolVar(&result.WithMetadata, `with-metadata`, false, `Sign the size and the permission bits of the files together with their content`)
+
+	signCmd.StringVar(&result.LogPath, `log`, ``, `Name of a signature log that an entry for the signatures file is appended to`)
+
+	signCmd.StringVar(&result.configPath, `config`, ``, `Name of a configuration file with defaults for the options (default '`+defaultConfigFileName+`' in the current directory, if present)`)
+
 	signCmd.SortFlags = true
 
 	return result
@@ -140,56 +210,121 @@ func (cl *SignCommandLine) PrintUsage() {
 
 // ExtractCommandData extracts the data that are needed for the command from the command line.
 func (cl *SignCommandLine) ExtractCommandData() error {
-	var err error
+	// 1. Options that are not specified on the command line are taken from the configuration file.
+	err := cl.applyConfig()
+	if err != nil {
+		return err
+	}
 
-	// 1. Build signatures file name.
+	// 2. Build signatures file name.
 	cl.SignaturesFileName = cl.prefix + signaturesFileNameSuffix
 
-	// 2. The signatures file must be written to the current directory.
+	// 3. The signatures file must be written to the current directory.
 	err = checkSignaturesFileName(cl.SignaturesFileName)
 	if err != nil {
 		return err
 	}
 
-	// 3. The signatures file must always be excluded.
-	_ = cl.excludeFileList.Set(cl.SignaturesFileName)
-
 	// 4. Get signature type.
 	cl.SignatureType, err = convertSignatureType(strings.ToLower(cl.signatureTypeText))
 	if err != nil {
 		return err
 	}
 
-	// 5. Read file names from command line, StdIn and options.
+	// 5. Get the chunk size of the Merkle tree, if one is used.
+	cl.ChunkSize, err = cl.getChunkSize()
+	if err != nil {
+		return err
+	}
+
+	// 6. Get the time after which the signatures expire, if they expire.
+	cl.NotAfter, err = cl.getNotAfter()
+	if err != nil {
+		return err
+	}
+
+	// 7. Metadata and symbolic links can only be recorded for files that are signed and verified in the file system.
+	cl.SymlinkMode, err = convertSymlinkMode(strings.ToLower(cl.symlinksText))
+	if err != nil {
+		return err
+	}
+
+	if cl.readStdInData || len(cl.ArchivePath) != 0 || len(cl.IntoArchivePath) != 0 {
+		if cl.WithMetadata {
+			return errors.New(`Option 'with-metadata' must not be specified together with option 'stdin-data' or archive options`)
+		}
+
+		if cl.SymlinkMode != signaturehandler.SymlinkModeFollow {
+			return errors.New(`Option 'symlinks' must not be specified together with option 'stdin-data' or archive options`)
+		}
+	}
+
+	// 8. Data from stdin are signed under a name, so no file selection is possible.
+	cl.StdinDataName, err = getStdinDataName(cl.readStdInData, cl.asName)
+	if err != nil {
+		return err
+	}
+
+	if cl.readStdInData {
+		if len(cl.ArchivePath) != 0 || len(cl.IntoArchivePath) != 0 {
+			return errors.New(`Option 'stdin-data' must not be specified together with archive options`)
+		}
+
+		if cl.StdinDataName == cl.SignaturesFileName {
+			return errors.New(`Data from stdin must not have the name of the signatures file`)
+		}
+
+		return cl.checkNoFileSelection(`option 'stdin-data'`)
+	}
+
+	// 9. The entries of an archive are signed as a whole, so no file selection is possible.
+	if len(cl.ArchivePath) != 0 {
+		if len(cl.IntoArchivePath) != 0 {
+			return errors.New(`Options 'archive' and 'into-archive' must not be specified together`)
+		}
+
+		return cl.checkNoFileSelection(`an archive`)
+	}
+
+	// 10. The file selection options from the configuration file are only used, if files are selected.
+	err = cl.applyFileSelectionConfig()
+	if err != nil {
+		return err
+	}
+
+	// 11. The signatures file must always be excluded.
+	_ = cl.excludeFileList.Set(cl.SignaturesFileName)
+
+	// 12. Read file names from command line, StdIn and options.
 	var fileSpecs []string
-	fileSpecs, err = getFileSpecsFromCmdLine(cl.fs.Args(), cl.fromFileName, cl.readStdIn)
+	fileSpecs, err = getFileSpecsFromCmdLine(cl.fs.Args(), cl.fromFileName, cl.readStdIn, cl.nullSeparated)
 	if err != nil {
 		return err
 	}
 
-	// 6. Move any command line wild cards to the includeFileList.
+	// 13. Move any command line wild cards to the includeFileList.
 	fileSpecs = moveWildCardFileSpecs(fileSpecs, cl.includeFileList)
 
-	// 7. Check for path separators in includes and excludes.
+	// 14. Check for path separators in includes and excludes.
 	err = checkExcludesIncludes(cl.excludeFileList.Elements(), cl.includeFileList.Elements(), cl.excludeDirList.Elements(), cl.includeDirList.Elements())
 	if err != nil {
 		return err
 	}
 
-	// 8. Convert file specs to absolute path names.
+	// 15. Convert file specs to absolute path names.
 	fileSpecs, err = makeAbsFileSpecs(fileSpecs)
 	if err != nil {
 		return err
 	}
 
-	// 9. Get the real path names for the file specifications.
+	// 16. Get the real path names for the file specifications.
 	var filePaths *set.Set[string]
 	filePaths, err = getRealFilePathsFromSpecs(fileSpecs, cl.excludeDirList.Elements(), cl.excludeFileList.Elements())
 	if err != nil {
 		return err
 	}
 
-	// 10. If no files are specified, or any include "include" is specified, scan the current directory.
+	// 17. If no files are specified, or any include "include" is specified, scan the current directory.
 	var scanPaths *set.Set[string]
 	if filePaths.Size() == 0 || cl.includeFileList.Size() != 0 || cl.includeDirList.Size() != 0 {
 		scanPaths, err = filehelper.ScanDir(
@@ -198,6 +333,7 @@ func (cl *SignCommandLine) ExtractCommandData() error {
 			cl.includeDirList.Elements(),
 			cl.excludeDirList.Elements(),
 			cl.doRecursion,
+			cl.respectGitignore,
 		)
 		if err != nil {
 			return err
@@ -207,14 +343,123 @@ func (cl *SignCommandLine) ExtractCommandData() error {
 		scanPaths = set.New[string]()
 	}
 
-	// 11. Combine the two file lists and return.
-	cl.FileList = filePaths.Union(scanPaths).Elements()
+	// 18. Combine the two file lists.
+	filePaths = filePaths.Union(scanPaths)
+
+	// 19. The ignore files that have been applied are always signed, so that their content is protected.
+	for _, ignoreFilePath := range filehelper.IgnoreFilePaths() {
+		filePaths.Add(ignoreFilePath)
+	}
+
+	// 20. An archive that is written must not contain itself.
+	if len(cl.IntoArchivePath) != 0 {
+		err = removeFilePath(filePaths, cl.IntoArchivePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	cl.FileList = filePaths.Elements()
 
 	return nil
 }
 
 // ******** Private functions ********
 
+// getChunkSize returns the chunk size of the Merkle tree or 0, if no Merkle tree is used.
+func (cl *SignCommandLine) getChunkSize() (int64, error) {
+	if !cl.useMerkle {
+		if cl.fs.Changed(`chunk-size`) {
+			return 0, errors.New(`Option 'chunk-size' is only valid together with option 'merkle'`)
+		}
+
+		return 0, nil
+	}
+
+	chunkSize, err := parseSize(cl.chunkSizeText)
+	if err != nil {
+		return 0, err
+	}
+
+	return chunkSize, filehasher.CheckChunkSize(chunkSize)
+}
+
+// getNotAfter returns the time after which the signatures expire or the zero time, if they do not expire.
+func (cl *SignCommandLine) getNotAfter() (time.Time, error) {
+	if len(cl.validForText) != 0 && len(cl.notAfterText) != 0 {
+		return time.Time{}, errors.New(`Options 'valid-for' and 'not-after' must not be specified together`)
+	}
+
+	var result time.Time
+	switch {
+	case len(cl.validForText) != 0:
+		validFor, err := parseDuration(cl.validForText)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		result = time.Now().Add(validFor)
+
+	case len(cl.notAfterText) != 0:
+		var err error
+		result, err = parseTime(cl.notAfterText)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if !result.After(time.Now()) {
+			return time.Time{}, fmt.Errorf(`Time in option 'not-after' is not in the future: '%s'`, cl.notAfterText)
+		}
+
+	default:
+		return time.Time{}, nil
+	}
+
+	// The expiry is stored with a precision of seconds.
+	return result.Truncate(time.Second), nil
+}
+
+// checkNoFileSelection checks that no file selection options are present.
+// The text describes what file selection options must not be specified with.
+func (cl *SignCommandLine) checkNoFileSelection(withText string) error {
+	if cl.fs.NArg() != 0 ||
+		len(cl.fromFileName) != 0 ||
+		cl.readStdIn ||
+		cl.nullSeparated ||
+		cl.doRecursion ||
+		cl.respectGitignore ||
+		cl.excludeFileList.HasElements() ||
+		cl.includeFileList.HasElements() ||
+		cl.excludeDirList.HasElements() ||
+		cl.includeDirList.HasElements() {
+		return fmt.Errorf(`Files and file selection options must not be specified together with %s`, withText)
+	}
+
+	return nil
+}
+
+// removeFilePath removes the file with the given path from a set of file paths.
+func removeFilePath(filePaths *set.Set[string], filePath string) error {
+	absFilePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return err
+	}
+
+	var absPath string
+	for _, aPath := range filePaths.Elements() {
+		absPath, err = filepath.Abs(aPath)
+		if err != nil {
+			return err
+		}
+
+		if absPath == absFilePath {
+			filePaths.Remove(aPath)
+		}
+	}
+
+	return nil
+}
+
 // convertSignatureType converts the signature type text into a SignatureType value.
 func convertSignatureType(signatureTypeText string) (signaturehandler.SignatureType, error) {
 	switch signatureTypeText {
@@ -229,6 +474,23 @@ func convertSignatureType(signatureTypeText string) (signaturehandler.SignatureT
 	}
 }
 
+// convertSymlinkMode converts the text of the symlinks option into a symbolic link mode.
+func convertSymlinkMode(symlinksText string) (signaturehandler.SymlinkMode, error) {
+	switch symlinksText {
+	case `follow`:
+		return signaturehandler.SymlinkModeFollow, nil
+
+	case `record`:
+		return signaturehandler.SymlinkModeRecord, nil
+
+	case `reject`:
+		return signaturehandler.SymlinkModeReject, nil
+
+	default:
+		return signaturehandler.SymlinkModeFollow, fmt.Errorf(`Invalid symbolic link treatment: '%s'`, symlinksText)
+	}
+}
+
 // moveWildCardFileSpecs moves wild card file specifications to the includeFileList
 func moveWildCardFileSpecs(fileSpecs []string, includeFileList *flaglist.FileSystemFlagList) []string {
 	resultList := make([]string, 0, len(fileSpecs))
@@ -243,7 +505,7 @@ func moveWildCardFileSpecs(fileSpecs []string, includeFileList *flaglist.FileSys
 	return resultList
 }
 
-// checkExcludesIncludes checks exclude and include list for path separators.
+// checkExcludesIncludes checks the patterns in the exclude and include lists.
 func checkExcludesIncludes(excludeFileList []string, includeFileList []string, excludeDirList []string, includeDirList []string) error {
 	err := checkTypeList(excludeType, fileObject, excludeFileList)
 	if err != nil {
@@ -268,11 +530,15 @@ func checkExcludesIncludes(excludeFileList []string, includeFileList []string, e
 	return nil
 }
 
-// checkTypeList checks if a pattern contains a path separator.
+// checkTypeList checks if the patterns are valid.
+// A pattern with a path separator must be a path pattern relative to the current directory.
 func checkTypeList(listType string, listObject string, excludeFileList []string) error {
 	for _, pattern := range excludeFileList {
-		if !filehelper.IsFileName(pattern) {
-			return fmt.Errorf(`Pattern '%s' in %sclude %s option must be a file name pattern`, pattern, listType, listObject)
+		if filehelper.IsPathPattern(pattern) {
+			err := filehelper.CheckPathPattern(pattern)
+			if err != nil {
+				return fmt.Errorf(`Invalid pattern in %sclude %s option: %w`, listType, listObject, err)
+			}
 		}
 	}
 
@@ -280,22 +546,31 @@ func checkTypeList(listType string, listObject string, excludeFileList []string)
 }
 
 // getFileSpecsFromCmdLine gathers all file specifications from the command line.
-func getFileSpecsFromCmdLine(args []string, fromFileName string, readStdIn bool) ([]string, error) {
+func getFileSpecsFromCmdLine(args []string, fromFileName string, readStdIn bool, nullSeparated bool) ([]string, error) {
 	var err error
 	var fileSpecs []string
 
-	// 1. See if there is a file that contains file names.
+	// 1. If 1. argument on the command line is '-' set readStdIn.
+	if len(args) != 0 && args[0] == readFromStdInArg {
+		readStdIn = true
+		args = args[1:] // Set args to files specs remaining after '-', if any.
+	}
+
+	// 2. NUL separation only applies to lists of file names.
+	if nullSeparated && len(fromFileName) == 0 && !readStdIn {
+		return nil, errors.New(`Option 'null' must only be specified together with option 'from-file' or 'stdin'`)
+	}
+
+	// 3. See if there is a file that contains file names.
 	if len(fromFileName) != 0 {
-		fileSpecs, err = addFileSpecsFromFileName(fromFileName, fileSpecs)
+		fileSpecs, err = addFileSpecsFromFileName(fromFileName, nullSeparated, fileSpecs)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// 2. Add file names from StdIn and the command line.
-	fileSpecs = addFileSpecsFromCmdLineAndStdIn(readStdIn, args, fileSpecs)
-
-	return fileSpecs, nil
+	// 4. Add file names from StdIn and the command line.
+	return addFileSpecsFromCmdLineAndStdIn(readStdIn, nullSeparated, args, fileSpecs)
 }
 
 // getRealFilePathsFromSpecs returns all file paths that match the supplied file specifications.
@@ -374,32 +649,21 @@ func makeAbsFileSpecs(fileSpecs []string) ([]string, error) {
 }
 
 // addFileSpecsFromCmdLineAndStdIn adds files from StdIn and from the command line.
-func addFileSpecsFromCmdLineAndStdIn(readStdIn bool, args []string, fileSpecs []string) []string {
-	// Are there any arguments?
-	if len(args) != 0 {
-		// If 1. argument on the command line is '-' set readStdIn.
-		if args[0] == readFromStdInArg {
-			readStdIn = true
-			args = args[1:] // Set args to files specs remaining after '-', if any.
-		}
-
-		if len(args) != 0 {
-			// Read files from command line
-			fileSpecs = append(fileSpecs, args...)
-		}
-	}
+func addFileSpecsFromCmdLineAndStdIn(readStdIn bool, nullSeparated bool, args []string, fileSpecs []string) ([]string, error) {
+	// Read files from command line
+	fileSpecs = append(fileSpecs, args...)
 
 	// Read file names from StdIn
 	if readStdIn {
-		fileSpecs = addFilesFromFile(os.Stdin, fileSpecs)
+		return addFilesFromFile(os.Stdin, nullSeparated, fileSpecs)
 	}
 
-	return fileSpecs
+	return fileSpecs, nil
 }
 
 // addFileSpecsFromFileName reads the contents of the file with the supplied file name
 // and adds them to the given fileLines slice. It returns the updated fileLines slice.
-func addFileSpecsFromFileName(fromFileName string, fileSpecs []string) ([]string, error) {
+func addFileSpecsFromFileName(fromFileName string, nullSeparated bool, fileSpecs []string) ([]string, error) {
 	readFile, err := os.Open(fromFileName)
 
 	if err != nil {
@@ -408,20 +672,48 @@ func addFileSpecsFromFileName(fromFileName string, fileSpecs []string) ([]string
 
 	defer filehelper.CloseFile(readFile)
 
-	fileSpecs = addFilesFromFile(readFile, fileSpecs)
-
-	return fileSpecs, nil
+	return addFilesFromFile(readFile, nullSeparated, fileSpecs)
 }
 
-// addFilesFromFile reads the content of the given os.File and appends each line to the provided fileLines slice.
+// addFilesFromFile reads the content of the given os.File and appends each file name to the provided fileLines slice.
+// File names are either separated by line ends or by NUL characters. Empty file names are skipped.
+// bufio.ScanLines also removes a carriage return before a line end, so lists written on Windows can be read.
 // It returns the updated fileLines slice.
-func addFilesFromFile(fromFile *os.File, fileSpecs []string) []string {
+func addFilesFromFile(fromFile *os.File, nullSeparated bool, fileSpecs []string) ([]string, error) {
 	fileScanner := bufio.NewScanner(fromFile)
-	fileScanner.Split(bufio.ScanLines)
+	if nullSeparated {
+		fileScanner.Split(scanNullSeparated)
+	} else {
+		fileScanner.Split(bufio.ScanLines)
+	}
 
+	var fileSpec string
 	for fileScanner.Scan() {
-		fileSpecs = append(fileSpecs, fileScanner.Text())
+		fileSpec = fileScanner.Text()
+		if len(fileSpec) != 0 {
+			fileSpecs = append(fileSpecs, fileSpec)
+		}
+	}
+
+	return fileSpecs, fileScanner.Err()
+}
+
+// scanNullSeparated is a split function for a bufio.Scanner that returns
+// each text that is terminated by a NUL character.
+func scanNullSeparated(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+
+	// The last text need not be terminated by a NUL character.
+	if atEOF {
+		return len(data), data, nil
 	}
 
-	return fileSpecs
+	// Request more data.
+	return 0, nil, nil
 }
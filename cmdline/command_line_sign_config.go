@@ -0,0 +1,190 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.1
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.0.1: Apply file selection options separately.
+//
+
+package cmdline
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"filesigner/filehelper"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+)
+
+// ******** Private types ********
+
+// signConfig contains the defaults for the "sign" command that are read from a configuration file.
+// Each field has the name of the corresponding option. Fields that are not present are not used.
+type signConfig struct {
+	Algorithm        *string  `json:"algorithm"`
+	Name             *string  `json:"name"`
+	Recurse          *bool    `json:"recurse"`
+	RespectGitignore *bool    `json:"respectGitignore"`
+	IncludeFile      []string `json:"includeFile"`
+	ExcludeFile      []string `json:"excludeFile"`
+	IncludeDir       []string `json:"includeDir"`
+	ExcludeDir       []string `json:"excludeDir"`
+	Quiet            *bool    `json:"quiet"`
+	Words            *bool    `json:"words"`
+}
+
+// ******** Private constants ********
+
+// defaultConfigFileName is the name of the configuration file that is read from the current directory,
+// if no configuration file is specified.
+const defaultConfigFileName = `filesigner.json`
+
+// maxConfigFileSize is the maximum size of a configuration file.
+const maxConfigFileSize = 1 << 20
+
+// ******** Private functions ********
+
+// applyConfig sets the options from the configuration file that have not been specified on the command line.
+// The file selection options are only set by applyFileSelectionConfig, as they must not be used
+// when data from stdin or an archive are signed.
+// A missing default configuration file is not an error.
+func (cl *SignCommandLine) applyConfig() error {
+	configPath := cl.configPath
+	isDefault := len(configPath) == 0
+	if isDefault {
+		configPath = defaultConfigFileName
+	}
+
+	config, err := readSignConfig(configPath)
+	if err != nil {
+		if isDefault && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf(`Could not read configuration file '%s': %w`, configPath, err)
+	}
+
+	cl.ConfigPath = configPath
+	cl.config = config
+
+	err = errors.Join(
+		cl.setStringDefault(`algorithm`, config.Algorithm),
+		cl.setStringDefault(`name`, config.Name),
+		cl.setBoolDefault(`quiet`, config.Quiet),
+		cl.setBoolDefault(`words`, config.Words),
+	)
+	if err != nil {
+		return fmt.Errorf(`Invalid value in configuration file '%s': %w`, configPath, err)
+	}
+
+	return nil
+}
+
+// applyFileSelectionConfig sets the file selection options from the configuration file
+// that have not been specified on the command line.
+func (cl *SignCommandLine) applyFileSelectionConfig() error {
+	if cl.config == nil {
+		return nil
+	}
+
+	err := errors.Join(
+		cl.setBoolDefault(`recurse`, cl.config.Recurse),
+		cl.setBoolDefault(`respect-gitignore`, cl.config.RespectGitignore),
+		cl.setListDefault(`include-file`, cl.config.IncludeFile),
+		cl.setListDefault(`exclude-file`, cl.config.ExcludeFile),
+		cl.setListDefault(`include-dir`, cl.config.IncludeDir),
+		cl.setListDefault(`exclude-dir`, cl.config.ExcludeDir),
+	)
+	if err != nil {
+		return fmt.Errorf(`Invalid value in configuration file '%s': %w`, cl.ConfigPath, err)
+	}
+
+	return nil
+}
+
+// setStringDefault sets a string option, if it has not been specified on the command line.
+func (cl *SignCommandLine) setStringDefault(name string, value *string) error {
+	if value == nil || cl.fs.Changed(name) {
+		return nil
+	}
+
+	return cl.fs.Set(name, *value)
+}
+
+// setBoolDefault sets a boolean option, if it has not been specified on the command line.
+func (cl *SignCommandLine) setBoolDefault(name string, value *bool) error {
+	if value == nil || cl.fs.Changed(name) {
+		return nil
+	}
+
+	return cl.fs.Set(name, strconv.FormatBool(*value))
+}
+
+// setListDefault sets the values of a list option, if it has not been specified on the command line.
+// The values from the command line replace the values from the configuration file.
+func (cl *SignCommandLine) setListDefault(name string, values []string) error {
+	if cl.fs.Changed(name) {
+		return nil
+	}
+
+	for _, value := range values {
+		err := cl.fs.Set(name, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readSignConfig reads a configuration file.
+func readSignConfig(configPath string) (*signConfig, error) {
+	size, err := filehelper.FileSize(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > maxConfigFileSize {
+		return nil, errors.New(`File is too large`)
+	}
+
+	var content []byte
+	content, err = os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &signConfig{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(result)
+	if err != nil {
+		return nil, fmt.Errorf(`Invalid format: %w`, err)
+	}
+
+	return result, nil
+}
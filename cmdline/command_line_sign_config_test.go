@@ -0,0 +1,175 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package cmdline
+
+import (
+	"filesigner/signaturehandler"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// ******** Private constants ********
+
+// testConfig is a configuration file with general and file selection options.
+const testConfig = `{
+   "algorithm": "ecdsap521",
+   "name": "config",
+   "recurse": true,
+   "excludeFile": ["*.log"],
+   "quiet": true
+}`
+
+// ******** Test functions ********
+
+func TestConfigFileSelection(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeTestFiles(t)
+
+	cl := extractSignCommandData(t)
+
+	if cl.SignaturesFileName != `config`+signaturesFileNameSuffix {
+		t.Fatalf(`Signatures file name is '%s'`, cl.SignaturesFileName)
+	}
+
+	if cl.SignatureType != signaturehandler.SignatureTypeEcDsaP521 {
+		t.Fatalf(`Signature type %d has not been taken from the configuration file`, cl.SignatureType)
+	}
+
+	if !cl.BeQuiet {
+		t.Fatal(`Option 'quiet' has not been taken from the configuration file`)
+	}
+
+	checkFileList(t, cl.FileList, []string{`a.txt`, filepath.Join(`sub`, `b.txt`)}, []string{`c.log`})
+}
+
+func TestCommandLineWinsOverConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeTestFiles(t)
+
+	cl := extractSignCommandData(t,
+		`--algorithm`, `ed25519`,
+		`--name`, `cmd`,
+		`--recurse=false`,
+		`--exclude-file`, `*.txt`,
+		`--quiet=false`)
+
+	if cl.SignaturesFileName != `cmd`+signaturesFileNameSuffix {
+		t.Fatalf(`Signatures file name is '%s'`, cl.SignaturesFileName)
+	}
+
+	if cl.SignatureType != signaturehandler.SignatureTypeEd25519 {
+		t.Fatalf(`Signature type %d has not been taken from the command line`, cl.SignatureType)
+	}
+
+	if cl.BeQuiet {
+		t.Fatal(`Option 'quiet' has not been taken from the command line`)
+	}
+
+	// The exclusions of the command line replace the ones of the configuration file.
+	checkFileList(t, cl.FileList, []string{`c.log`}, []string{`a.txt`, filepath.Join(`sub`, `b.txt`)})
+}
+
+func TestConfigWithStdinData(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeTestFileContent(t, defaultConfigFileName, testConfig)
+
+	cl := extractSignCommandData(t, `--stdin-data`, `--as`, `data.bin`)
+
+	if cl.StdinDataName != `data.bin` || cl.SignaturesFileName != `config`+signaturesFileNameSuffix {
+		t.Fatalf(`Wrong names '%s' and '%s'`, cl.StdinDataName, cl.SignaturesFileName)
+	}
+}
+
+func TestConfigWithArchive(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeTestFileContent(t, defaultConfigFileName, testConfig)
+
+	cl := extractSignCommandData(t, `--archive`, `a.zip`)
+
+	if cl.ArchivePath != `a.zip` {
+		t.Fatalf(`Archive path is '%s'`, cl.ArchivePath)
+	}
+}
+
+func TestFileSelectionWithStdinData(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	cl := NewSignCommandLine()
+	err, _ := cl.Parse([]string{`--stdin-data`, `--as`, `data.bin`, `--recurse`})
+	if err != nil {
+		t.Fatalf(`Parse failed: %v`, err)
+	}
+
+	err = cl.ExtractCommandData()
+	if err == nil {
+		t.Fatal(`File selection option on the command line not detected`)
+	}
+}
+
+// ******** Private functions ********
+
+// writeTestFiles writes the configuration file and the files that are selected.
+func writeTestFiles(t *testing.T) {
+	writeTestFileContent(t, defaultConfigFileName, testConfig)
+	writeTestFile(t, `a.txt`)
+	writeTestFile(t, `c.log`)
+	writeTestFile(t, filepath.Join(`sub`, `b.txt`))
+}
+
+// extractSignCommandData parses a sign command line and extracts its data.
+func extractSignCommandData(t *testing.T, args ...string) *SignCommandLine {
+	cl := NewSignCommandLine()
+	err, _ := cl.Parse(args)
+	if err != nil {
+		t.Fatalf(`Parse failed: %v`, err)
+	}
+
+	err = cl.ExtractCommandData()
+	if err != nil {
+		t.Fatalf(`ExtractCommandData failed: %v`, err)
+	}
+
+	return cl
+}
+
+// checkFileList checks that the file list contains the expected files and not the excluded files.
+func checkFileList(t *testing.T, fileList []string, expected []string, excluded []string) {
+	for _, filePath := range expected {
+		if !slices.Contains(fileList, filePath) {
+			t.Fatalf(`File list %v does not contain '%s'`, fileList, filePath)
+		}
+	}
+
+	for _, filePath := range excluded {
+		if slices.Contains(fileList, filePath) {
+			t.Fatalf(`File list %v contains '%s'`, fileList, filePath)
+		}
+	}
+}
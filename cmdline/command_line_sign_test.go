@@ -0,0 +1,112 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package cmdline
+
+import (
+	"bufio"
+	"filesigner/filehelper"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ******** Test functions ********
+
+func TestScanNullSeparated(t *testing.T) {
+	checkScanNullSeparated(t, "a.txt\x00dir/b c.txt\x00", []string{`a.txt`, `dir/b c.txt`})
+}
+
+func TestScanNullSeparatedWithoutTerminator(t *testing.T) {
+	checkScanNullSeparated(t, "a.txt\x00b.txt", []string{`a.txt`, `b.txt`})
+}
+
+func TestScanNullSeparatedKeepsLineEnds(t *testing.T) {
+	// Line ends are valid characters in file names, when the names are separated by NUL characters.
+	checkScanNullSeparated(t, "a\r\nb\x00c\n\x00", []string{"a\r\nb", "c\n"})
+}
+
+func TestScanNullSeparatedEmpty(t *testing.T) {
+	checkScanNullSeparated(t, ``, nil)
+}
+
+func TestAddFilesFromFileNullSeparated(t *testing.T) {
+	checkAddFilesFromFile(t, "\x00a.txt\x00\x00b.txt", true, []string{`a.txt`, `b.txt`})
+}
+
+func TestAddFilesFromFileCrLf(t *testing.T) {
+	checkAddFilesFromFile(t, "a.txt\r\nb.txt\r\n\r\nc.txt", false, []string{`a.txt`, `b.txt`, `c.txt`})
+}
+
+func TestAddFilesFromFileLf(t *testing.T) {
+	checkAddFilesFromFile(t, "\na.txt\n\nb.txt\n", false, []string{`a.txt`, `b.txt`})
+}
+
+// ******** Private functions ********
+
+// checkScanNullSeparated checks that scanning the data results in the expected texts.
+func checkScanNullSeparated(t *testing.T, data string, expected []string) {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Split(scanNullSeparated)
+
+	var texts []string
+	for scanner.Scan() {
+		texts = append(texts, scanner.Text())
+	}
+
+	if scanner.Err() != nil {
+		t.Fatalf(`Scanning failed: %v`, scanner.Err())
+	}
+
+	if !slices.Equal(texts, expected) {
+		t.Fatalf(`Scanning %q resulted in %q instead of %q`, data, texts, expected)
+	}
+}
+
+// checkAddFilesFromFile checks that reading a file list with the given content results in the expected file names.
+func checkAddFilesFromFile(t *testing.T, content string, nullSeparated bool, expected []string) {
+	t.Chdir(t.TempDir())
+	writeTestFileContent(t, `list.txt`, content)
+
+	f, err := os.Open(`list.txt`)
+	if err != nil {
+		t.Fatalf(`Could not open file list: %v`, err)
+	}
+	defer filehelper.CloseFile(f)
+
+	var fileSpecs []string
+	fileSpecs, err = addFilesFromFile(f, nullSeparated, nil)
+	if err != nil {
+		t.Fatalf(`Could not read file list: %v`, err)
+	}
+
+	if !slices.Equal(fileSpecs, expected) {
+		t.Fatalf(`File list %q resulted in %q instead of %q`, content, fileSpecs, expected)
+	}
+}
@@ -0,0 +1,161 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package cmdline
+
+import (
+	"errors"
+	"filesigner/truststore"
+	"fmt"
+	"github.com/spf13/pflag"
+	"os"
+	"strings"
+)
+
+// ******** Public constants ********
+
+// These are the subcommands of the "trust" command.
+const (
+	TrustSubcommandAdd    = `add`
+	TrustSubcommandList   = `list`
+	TrustSubcommandRemove = `remove`
+)
+
+// ******** Public types ********
+
+// TrustCommandLine is the object that contains all the data
+// to interpret a "trust" command line.
+type TrustCommandLine struct {
+	// Public elements
+	Subcommand string
+	Argument   string
+	Label      string
+	StorePath  string
+
+	// Private elements
+	fs *pflag.FlagSet
+}
+
+// ******** Public functions ********
+
+// NewTrustCommandLine sets up the flag parser for the "trust" command.
+func NewTrustCommandLine() *TrustCommandLine {
+	trustCmd := pflag.NewFlagSet(`trust`, pflag.ContinueOnError)
+
+	trustCmd.SetOutput(os.Stdout)
+
+	result := &TrustCommandLine{fs: trustCmd}
+
+	trustCmd.StringVar(&result.Label, `label`, ``, `Label of the verification id that is added`)
+
+	trustCmd.StringVar(&result.StorePath, `store`, ``, `Path of the trust store (default is 'filesigner/trust.json' in the user configuration directory)`)
+
+	trustCmd.SortFlags = true
+
+	return result
+}
+
+// Parse parses the command line according to the flag rules.
+// The first argument is the subcommand.
+func (cl *TrustCommandLine) Parse(args []string) (error, bool) {
+	if len(args) == 0 {
+		return errors.New(`Trust subcommand is missing`), false
+	}
+
+	cl.Subcommand = strings.ToLower(args[0])
+
+	err := cl.fs.Parse(args[1:])
+	if errors.Is(err, pflag.ErrHelp) {
+		return nil, true
+	}
+
+	return err, false
+}
+
+// PrintUsage prints the usage information for the command.
+func (cl *TrustCommandLine) PrintUsage() {
+	cl.fs.PrintDefaults()
+}
+
+// ExtractCommandData extracts the data that are needed for the command from the command line.
+func (cl *TrustCommandLine) ExtractCommandData() error {
+	var err error
+
+	// 1. Check the arguments of the subcommand.
+	switch cl.Subcommand {
+	case TrustSubcommandAdd:
+		err = cl.checkArguments(1, `Verification id`)
+		if err == nil && len(strings.TrimSpace(cl.Label)) == 0 {
+			err = errors.New(`Option 'label' is missing`)
+		}
+
+	case TrustSubcommandRemove:
+		err = cl.checkArguments(1, `Verification id or label`)
+
+	case TrustSubcommandList:
+		err = cl.checkArguments(0, ``)
+
+	default:
+		err = fmt.Errorf(`Unknown trust subcommand: '%s'`, cl.Subcommand)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if cl.fs.NArg() != 0 {
+		cl.Argument = strings.TrimSpace(cl.fs.Arg(0))
+	}
+
+	// 2. Get the path of the trust store.
+	if len(cl.StorePath) == 0 {
+		cl.StorePath, err = truststore.DefaultPath()
+	}
+
+	return err
+}
+
+// ******** Private functions ********
+
+// checkArguments checks the number of arguments of a subcommand and the options that are only valid for "add".
+func (cl *TrustCommandLine) checkArguments(count int, name string) error {
+	argCount := cl.fs.NArg()
+	if argCount < count {
+		return fmt.Errorf(`%s is missing`, name)
+	}
+
+	if argCount > count {
+		return errors.New(`Too many arguments`)
+	}
+
+	if cl.Subcommand != TrustSubcommandAdd && cl.fs.Changed(`label`) {
+		return fmt.Errorf(`Option 'label' is only valid for subcommand '%s'`, TrustSubcommandAdd)
+	}
+
+	return nil
+}
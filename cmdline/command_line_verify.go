@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,20 +20,39 @@
 //
 // Author: Frank Schwab
 //
-// Version: 2.0.0
+// Version: 2.10.1
 //
 // Change history:
 //    2024-02-08: V1.0.0: Created.
 //    2024-04-05: V1.0.1: Make Stdout the output destination for usage messages.
 //    2025-05-23: V2.0.0: Add verification id.
+//    2026-10-19: V2.1.0: Add archive option.
+//    2026-10-19: V2.2.0: Add stdin-data option.
+//    2026-10-19: V2.3.0: Add verification id source options.
+//    2026-10-19: V2.4.0: Add trust-store option.
+//    2026-10-19: V2.5.0: Add revocation list options.
+//    2026-10-19: V2.6.0: Add verification time option.
+//    2026-10-19: V2.7.0: Share verification flags with the watch command.
+//    2026-10-19: V2.8.0: Add notification options.
+//    2026-10-19: V2.9.0: Add metrics-file option.
+//    2026-10-19: V2.10.0: Verify more than one signatures file.
+//    2026-10-19: V2.10.1: Do not apply ignore files when discovering signatures files.
 //
 
 package cmdline
 
 import (
 	"errors"
+	"filesigner/flaglist"
+	"fmt"
 	"github.com/spf13/pflag"
+	"io/fs"
+	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 )
 
 // ******** Public types ********
@@ -43,11 +62,31 @@ import (
 type VerifyCommandLine struct {
 	// Public elements
 	SignaturesFileName string
+	ArchivePath        string
+	StdinDataName      string
+	IdFile             string
+	IdEnv              string
+	IdUrl              string
+	TrustStorePath     string
+	RevocationsPath    string
+	RevocationsId      string
+	VerificationTime   time.Time
+	NotifyUrl          string
+	NotifyKeyEnv       string
+	MetricsFilePath    string
 	BeQuiet            bool
 
+	// SignaturesFilePaths contains the paths of all signatures files that are verified.
+	// SignaturesFileName is the first of them.
+	SignaturesFilePaths []string
+
 	// Private elements
-	fs     *pflag.FlagSet
-	prefix string
+	fs            *pflag.FlagSet
+	prefixList    *flaglist.FileSystemFlagList
+	discover      bool
+	readStdInData bool
+	asName        string
+	atText        string
 }
 
 // ******** Public functions ********
@@ -60,9 +99,23 @@ func NewVerifyCommandLine() *VerifyCommandLine {
 
 	result := &VerifyCommandLine{fs: verifyCmd}
 
-	verifyCmd.StringVarP(&result.prefix, `name`, `m`, defaultSignaturesFileNamePrefix, `Prefix of the signatures file name`)
+	result.addVerificationFlags()
+
+	verifyCmd.BoolVar(&result.discover, `discover`, false, `Verify all signatures files in the current directory and its subdirectories`)
+
+	verifyCmd.StringVar(&result.ArchivePath, `archive`, ``, `Name of a zip or tar archive whose entries are verified`)
+
+	verifyCmd.StringVar(&result.atText, `at`, ``, `Time as of which the signatures are verified (default is now)`)
+
+	verifyCmd.StringVar(&result.NotifyUrl, `notify-url`, ``, `'http' or 'https' URL that a signed summary is sent to, if the verification is not successful`)
 
-	verifyCmd.BoolVarP(&result.BeQuiet, `quiet`, `q`, false, `Print only errors`)
+	verifyCmd.StringVar(&result.NotifyKeyEnv, `notify-key-env`, ``, `Name of an environment variable that contains the key for the signature of the summary`)
+
+	verifyCmd.StringVar(&result.MetricsFilePath, `metrics-file`, ``, `Path of a file that the metrics of the verification are written to in the Prometheus text format`)
+
+	verifyCmd.BoolVar(&result.readStdInData, `stdin-data`, false, `Verify the data read from stdin`)
+
+	verifyCmd.StringVar(&result.asName, `as`, ``, `Name under which the data from stdin have been signed`)
 
 	verifyCmd.SortFlags = true
 
@@ -90,14 +143,198 @@ func (cl *VerifyCommandLine) PrintUsage() {
 
 // ExtractCommandData returns the data that are needed for the command.
 func (cl *VerifyCommandLine) ExtractCommandData() error {
-	// 1. Build signatures file name.
-	cl.SignaturesFileName = cl.prefix + signaturesFileNameSuffix
+	// 1. Build signatures file names or find the signatures files.
+	err := cl.getSignaturesFilePaths()
+	if err != nil {
+		return err
+	}
+
+	if cl.HasManySignaturesFiles() {
+		if len(cl.ArchivePath) != 0 {
+			return errors.New(`Option 'archive' must not be specified for more than one signatures file`)
+		}
+
+		if cl.readStdInData {
+			return errors.New(`Option 'stdin-data' must not be specified for more than one signatures file`)
+		}
+	}
+
+	// 2. Only the first signatures file is used, if only one is verified.
+	cl.SignaturesFileName = cl.SignaturesFilePaths[0]
 
-	// 2. The signatures file must be written to the current directory.
-	err := checkSignaturesFileName(cl.SignaturesFileName)
+	// 3. Get the name of the data from stdin.
+	cl.StdinDataName, err = getStdinDataName(cl.readStdInData, cl.asName)
 	if err != nil {
 		return err
 	}
 
+	if cl.readStdInData && len(cl.ArchivePath) != 0 {
+		return errors.New(`Options 'stdin-data' and 'archive' must not be specified together`)
+	}
+
+	// 4. There may only be one source for the verification id.
+	if cl.IdSourceCount() > 1 {
+		return errors.New(`Only one of the options 'id-file', 'id-env' and 'id-url' may be specified`)
+	}
+
+	if cl.IdSourceCount() != 0 && len(cl.TrustStorePath) != 0 {
+		return errors.New(`Option 'trust-store' must not be specified together with a verification id option`)
+	}
+
+	// 5. A revocation list can only be used with its list id.
+	if (len(cl.RevocationsPath) == 0) != (len(cl.RevocationsId) == 0) {
+		return errors.New(`Options 'revocations' and 'revocations-id' must be specified together`)
+	}
+
+	// 6. Get the time as of which the signatures are verified.
+	if len(cl.atText) == 0 {
+		cl.VerificationTime = time.Now()
+	} else {
+		cl.VerificationTime, err = parseTime(cl.atText)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 7. A summary can only be sent with a key for its signature.
+	return cl.checkNotifyOptions()
+}
+
+// IdSourceCount returns the number of options that specify a source for the verification id.
+func (cl *VerifyCommandLine) IdSourceCount() int {
+	result := 0
+	for _, source := range []string{cl.IdFile, cl.IdEnv, cl.IdUrl} {
+		if len(source) != 0 {
+			result++
+		}
+	}
+
+	return result
+}
+
+// HasManySignaturesFiles checks if more than one signatures file may be verified.
+// This is the case if more than one name is specified or the signatures files are discovered.
+func (cl *VerifyCommandLine) HasManySignaturesFiles() bool {
+	return cl.discover || cl.prefixList.Size() > 1
+}
+
+// ******** Private type functions ********
+
+// getSignaturesFilePaths builds the signatures file names from the prefixes
+// or finds the signatures files in the current directory and its subdirectories.
+func (cl *VerifyCommandLine) getSignaturesFilePaths() error {
+	if cl.discover {
+		if cl.prefixList.HasElements() {
+			return errors.New(`Options 'discover' and 'name' must not be specified together`)
+		}
+
+		filePaths, err := findSignaturesFiles(`.`)
+		if err != nil {
+			return fmt.Errorf(`Could not find signatures files: %w`, err)
+		}
+
+		if len(filePaths) == 0 {
+			return errors.New(`No signatures files found`)
+		}
+
+		cl.SignaturesFilePaths = filePaths
+
+		return nil
+	}
+
+	prefixes := []string{defaultSignaturesFileNamePrefix}
+	if cl.prefixList.HasElements() {
+		prefixes = cl.prefixList.Elements()
+		slices.Sort(prefixes)
+	}
+
+	cl.SignaturesFilePaths = make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		signaturesFileName := prefix + signaturesFileNameSuffix
+
+		// The signatures files must be in the current directory.
+		err := checkSignaturesFileName(signaturesFileName)
+		if err != nil {
+			return err
+		}
+
+		cl.SignaturesFilePaths = append(cl.SignaturesFilePaths, signaturesFileName)
+	}
+
 	return nil
 }
+
+// checkNotifyOptions checks that the notification options are consistent.
+func (cl *VerifyCommandLine) checkNotifyOptions() error {
+	if len(cl.NotifyUrl) == 0 {
+		if len(cl.NotifyKeyEnv) != 0 {
+			return errors.New(`Option 'notify-key-env' must not be specified without option 'notify-url'`)
+		}
+
+		return nil
+	}
+
+	notifyUrl, err := url.Parse(cl.NotifyUrl)
+	if err != nil {
+		return fmt.Errorf(`Invalid notification URL '%s': %w`, cl.NotifyUrl, err)
+	}
+
+	scheme := strings.ToLower(notifyUrl.Scheme)
+	if scheme != `http` && scheme != `https` {
+		return fmt.Errorf(`Notification URL '%s' must have the scheme 'http' or 'https'`, cl.NotifyUrl)
+	}
+
+	if len(cl.NotifyKeyEnv) == 0 {
+		return errors.New(`Option 'notify-key-env' is missing`)
+	}
+
+	return nil
+}
+
+// addVerificationFlags adds the flags for the signatures file name, the verification id and the revocation list.
+func (cl *VerifyCommandLine) addVerificationFlags() {
+	cl.prefixList = flaglist.NewFileSystemFlagList()
+	cl.fs.VarP(cl.prefixList, `name`, `m`, `Prefix of the signatures file name (default 'filesigner')`)
+
+	cl.fs.BoolVarP(&cl.BeQuiet, `quiet`, `q`, false, `Print only errors`)
+
+	cl.fs.StringVar(&cl.IdFile, `id-file`, ``, `Name of a file that contains the verification id`)
+
+	cl.fs.StringVar(&cl.IdEnv, `id-env`, ``, `Name of an environment variable that contains the verification id`)
+
+	cl.fs.StringVar(&cl.IdUrl, `id-url`, ``, `'https' or 'file' URL of a list of context ids and verification ids`)
+
+	cl.fs.StringVar(&cl.TrustStorePath, `trust-store`, ``, `Path of the trust store that is used if no verification id is specified`)
+
+	cl.fs.StringVar(&cl.RevocationsPath, `revocations`, ``, `Path of a revocation list that must not contain the verification id`)
+
+	cl.fs.StringVar(&cl.RevocationsId, `revocations-id`, ``, `List id of the revocation list`)
+}
+
+// ******** Private functions ********
+
+// findSignaturesFiles returns the paths of all signatures files in a directory and its subdirectories in sorted order.
+// Ignore files and .gitignore files are deliberately not applied,
+// as otherwise anyone who can write such a file could hide a signatures file from the verification.
+// Symbolic links are not followed.
+func findSignaturesFiles(rootPath string) ([]string, error) {
+	result := make([]string, 0)
+	err := filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() && strings.HasSuffix(d.Name(), signaturesFileNameSuffix) {
+			result = append(result, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(result)
+
+	return result, nil
+}
@@ -0,0 +1,111 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package cmdline
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// ******** Test functions ********
+
+func TestDiscoverIgnoresIgnoreFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	writeTestFile(t, `filesigner-signatures.json`)
+	writeTestFile(t, filepath.Join(`comp`, `comp-signatures.json`))
+	writeTestFile(t, filepath.Join(`comp`, `f.txt`))
+	writeTestFile(t, filepath.Join(`other`, `deep`, `other-signatures.json`))
+	writeTestFile(t, filepath.Join(`other`, `not-a-signatures.txt`))
+
+	// Ignore files must not hide signatures files from the verification.
+	writeTestFileContent(t, `.filesignerignore`, "comp/\nother/\n")
+	writeTestFileContent(t, `.gitignore`, "comp/\n*-signatures.json\n")
+
+	cl := NewVerifyCommandLine()
+	err, _ := cl.Parse([]string{`--discover`})
+	if err != nil {
+		t.Fatalf(`Parse failed: %v`, err)
+	}
+
+	err = cl.ExtractCommandData()
+	if err != nil {
+		t.Fatalf(`ExtractCommandData failed: %v`, err)
+	}
+
+	expected := []string{
+		filepath.Join(`comp`, `comp-signatures.json`),
+		`filesigner-signatures.json`,
+		filepath.Join(`other`, `deep`, `other-signatures.json`),
+	}
+	if !slices.Equal(cl.SignaturesFilePaths, expected) {
+		t.Fatalf(`Discovered %v instead of %v`, cl.SignaturesFilePaths, expected)
+	}
+
+	if !cl.HasManySignaturesFiles() {
+		t.Fatal(`Discovery does not count as many signatures files`)
+	}
+}
+
+func TestDiscoverWithoutSignaturesFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	cl := NewVerifyCommandLine()
+	err, _ := cl.Parse([]string{`--discover`})
+	if err != nil {
+		t.Fatalf(`Parse failed: %v`, err)
+	}
+
+	err = cl.ExtractCommandData()
+	if err == nil {
+		t.Fatal(`Missing signatures files are not reported`)
+	}
+}
+
+// ******** Private functions ********
+
+// writeTestFile writes an empty test file and creates its directory.
+func writeTestFile(t *testing.T, filePath string) {
+	writeTestFileContent(t, filePath, ``)
+}
+
+// writeTestFileContent writes a test file with the given content and creates its directory.
+func writeTestFileContent(t *testing.T, filePath string, content string) {
+	err := os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		t.Fatalf(`Could not create directory for '%s': %v`, filePath, err)
+	}
+
+	err = os.WriteFile(filePath, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf(`Could not write file '%s': %v`, filePath, err)
+	}
+}
@@ -0,0 +1,95 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Reject more than one name.
+//
+
+package cmdline
+
+import (
+	"errors"
+	"github.com/spf13/pflag"
+	"os"
+	"time"
+)
+
+// ******** Public types ********
+
+// WatchCommandLine is the object that contains all the data
+// to interpret a "watch" command line.
+// It has the verification options of the "verify" command that apply to files in the current directory.
+type WatchCommandLine struct {
+	VerifyCommandLine
+
+	// Public elements
+	Interval time.Duration
+
+	// Private elements
+	intervalText string
+}
+
+// ******** Private constants ********
+
+// defaultWatchInterval is the default interval of the full verifications.
+const defaultWatchInterval = `1h`
+
+// ******** Public functions ********
+
+// NewWatchCommandLine sets up the flag parser for the "watch" command.
+func NewWatchCommandLine() *WatchCommandLine {
+	watchCmd := pflag.NewFlagSet(`watch`, pflag.ContinueOnError)
+
+	watchCmd.SetOutput(os.Stdout)
+
+	result := &WatchCommandLine{VerifyCommandLine: VerifyCommandLine{fs: watchCmd}}
+
+	result.addVerificationFlags()
+
+	watchCmd.StringVar(&result.intervalText, `interval`, defaultWatchInterval, `Interval of the full verifications (with unit 'm', 'h', 'd' or 'w')`)
+
+	watchCmd.SortFlags = true
+
+	return result
+}
+
+// ExtractCommandData extracts the data that are needed for the command from the command line.
+func (cl *WatchCommandLine) ExtractCommandData() error {
+	// 1. Get the data of the verification options.
+	err := cl.VerifyCommandLine.ExtractCommandData()
+	if err != nil {
+		return err
+	}
+
+	// 2. Only one signatures file can be watched.
+	if cl.HasManySignaturesFiles() {
+		return errors.New(`Only one name may be specified`)
+	}
+
+	// 3. Get the interval of the full verifications.
+	cl.Interval, err = parseDuration(cl.intervalText)
+
+	return err
+}
@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024-2025 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,22 +20,24 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.1.0
+// Version: 2.0.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
 //    2025-03-01: V1.1.0: Add message base.
+//    2026-10-19: V1.2.0: Add hash options.
+//    2026-10-19: V1.3.0: Add word form and hash of verification id.
+//    2026-10-19: V1.4.0: Print expiry of signatures.
+//    2026-10-19: V1.5.0: Get metadata of files, if they are recorded.
+//    2026-10-19: V1.6.0: Use symbolic link mode.
+//    2026-10-19: V2.0.0: Print results of api package.
 //
 
 package main
 
 import (
-	"filesigner/filehasher"
-	"filesigner/keyid"
+	"filesigner/api"
 	"filesigner/logger"
-	"filesigner/maphelper"
-	"filesigner/signaturehandler"
-	"filesigner/stringhelper"
 	"sort"
 )
 
@@ -50,49 +52,20 @@ func printSuccessList(operation string, successList []string) {
 	}
 }
 
-// printErrorList prints the errors that occurred during an operation.
-func printErrorList(errorList []error) {
-	for _, err := range errorList {
-		logger.PrintError(commonMsgBase+1, err.Error())
+// printFileErrors prints the errors that occurred for files.
+func printFileErrors(fileErrors []*api.FileError) {
+	for _, fe := range fileErrors {
+		logger.PrintError(commonMsgBase+2, fe.Error())
 	}
 }
 
-// existHashErrors checks if hash errors exist and prints them.
-func existHashErrors(hashResults map[string]*filehasher.HashResult) bool {
-	result := false
-
-	keyList := maphelper.SortedKeys(hashResults)
-
-	var hr *filehasher.HashResult
-	for _, filePath := range keyList {
-		hr = hashResults[filePath]
-		if hr.Err != nil {
-			logger.PrintErrorf(commonMsgBase+2, `Could not get hash of file '%s': %v`, hr.FilePath, hr.Err)
-			result = true
-		}
-	}
-
-	return result
-}
-
 // printMetaData prints the meta data of the signatures.
-func printMetaData(
-	signatureData *signaturehandler.SignatureData,
-	publicKeyBytes []byte) {
-	logger.PrintInfof(commonMsgBase+3, `Context id         : %s`, signatureData.ContextId)
-	logger.PrintInfof(commonMsgBase+4, `Public key id      : %s`, keyid.KeyId(publicKeyBytes))
-	logger.PrintInfof(commonMsgBase+5, `Signature timestamp: %s`, signatureData.Timestamp)
-	logger.PrintInfof(commonMsgBase+6, `Signature host name: %s`, signatureData.Hostname)
-}
-
-// makeVerificationId returns the verification id for the given data.
-func makeVerificationId(
-	signatureData *signaturehandler.SignatureData,
-	publicKeyBytes []byte) string {
-	return keyid.KeyId(
-		stringhelper.UnsafeStringBytes(signatureData.ContextId),
-		publicKeyBytes,
-		stringhelper.UnsafeStringBytes(signatureData.Timestamp),
-		stringhelper.UnsafeStringBytes(signatureData.Hostname),
-	)
+func printMetaData(signatureInfo *api.SignatureInfo) {
+	logger.PrintInfof(commonMsgBase+3, `Context id         : %s`, signatureInfo.ContextId)
+	logger.PrintInfof(commonMsgBase+4, `Public key id      : %s`, signatureInfo.PublicKeyId)
+	logger.PrintInfof(commonMsgBase+5, `Signature timestamp: %s`, signatureInfo.Timestamp)
+	logger.PrintInfof(commonMsgBase+6, `Signature host name: %s`, signatureInfo.Hostname)
+	if len(signatureInfo.NotAfter) != 0 {
+		logger.PrintInfof(commonMsgBase+7, `Signature expiry   : %s`, signatureInfo.NotAfter)
+	}
 }
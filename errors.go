@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2025 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2025-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,10 +20,34 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.24.0
 //
 // Change history:
 //    2025-05-25: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Describe archive option.
+//    2026-10-19: V1.2.0: Describe into-archive option.
+//    2026-10-19: V1.3.0: Describe Merkle tree options.
+//    2026-10-19: V1.4.0: Describe stdin-data option.
+//    2026-10-19: V1.5.0: Describe verification id options.
+//    2026-10-19: V1.6.0: Describe word form of verification id.
+//    2026-10-19: V1.7.0: Describe trust command.
+//    2026-10-19: V1.8.0: Describe signature log.
+//    2026-10-19: V1.9.0: Describe revocation list.
+//    2026-10-19: V1.10.0: Describe expiry of signatures.
+//    2026-10-19: V1.11.0: Describe with-metadata option.
+//    2026-10-19: V1.12.0: Describe symlinks option.
+//    2026-10-19: V1.13.0: Describe respect-gitignore option.
+//    2026-10-19: V1.14.0: Describe path patterns.
+//    2026-10-19: V1.15.0: Describe configuration file.
+//    2026-10-19: V1.16.0: Describe ignore files.
+//    2026-10-19: V1.17.0: Describe null option.
+//    2026-10-19: V1.18.0: Describe selfcheck command.
+//    2026-10-19: V1.19.0: Describe watch command.
+//    2026-10-19: V1.20.0: Describe notification options.
+//    2026-10-19: V1.21.0: Describe metrics-file option.
+//    2026-10-19: V1.22.0: Describe verification of more than one signatures file.
+//    2026-10-19: V1.23.0: Describe key and expiry of the revocation list.
+//    2026-10-19: V1.24.0: Describe return code of watch command.
 //
 
 package main
@@ -90,14 +114,30 @@ Sign files:
   If no file names are specified, all files in the current directory are signed.
   This can be modified by the exclude and include options.
   The '--recurse' option is only valid if there are either no files specified or if there are include options present.
+  If the '--respect-gitignore' option is specified, files and directories that are ignored by .gitignore files are skipped when directories are scanned.
   The files must be present in the current directory or one of its subdirectories.
   Specifying a file outside the current directory tree is an error.
   All file names that contain wildcards ('*', '?') are treated as if they were specified in an '--include-file' option.
+  Include and exclude patterns with a path separator are matched against the path relative to the current directory, where '**' matches any number of directories.
+  Options that are not specified are taken from the configuration file 'filesigner.json' in the current directory or the file in the '--config' option, if present.
+  Files and directories in '.filesignerignore' files are not signed, but the '.filesignerignore' files themselves are always signed.
+  With the '--null' option the file names read with '--from-file' or '--stdin' are separated by NUL characters instead of line ends.
+  If the '--archive' option is specified, all files in the archive are signed and no files or file selection options may be specified.
+  If the '--into-archive' option is specified, the selected files and the signatures file are written into a new zip or tar archive.
+  The signatures file is then not written to the current directory.
+  The '--chunk-size' option is only valid together with the '--merkle' option.
+  If the '--stdin-data' option is specified, the data from stdin are signed under the name in the '--as' option and no files or file selection options may be specified.
+  The verification id is printed both as groups of letters and digits and as a list of words. Both forms can be used interchangeably.
+  The options '--valid-for' and '--not-after' must not be specified together.
+  Times may be specified as '2006-01-02', '2006-01-02 15:04', '2006-01-02 15:04:05' or in RFC 3339 format.
+  If the '--log' option is specified, an entry for the signatures file is appended to the hash-chained signature log before the signatures file is written.
+  If the '--with-metadata' option is specified, the size and the permission bits of the files are signed, as well. On Windows only the size is signed.
+  The '--symlinks' option specifies if symbolic links are followed ('follow'), if their targets are signed ('record') or if they are not allowed ('reject').
 
 
 Verify files:
 `)
-	_, _ = fmt.Printf(`  %s verify {verificationId} [flags]`, myName)
+	_, _ = fmt.Printf(`  %s verify [verificationId] [flags]`, myName)
 	_, _ = fmt.Print(`
 
   with 'flags' being one or more of the following options:
@@ -105,8 +145,101 @@ Verify files:
 `)
 	vcl.PrintUsage()
 	_, _ = fmt.Print(`
-  The 'verificationId' is the verification id printed when the signatures were created.
+  The 'verificationId' is the verification id printed when the signatures were created, either as groups of letters and digits or as a list of words.
+  Instead of the 'verificationId' exactly one of the options '--id-file', '--id-env' or '--id-url' may be specified.
+  If neither a 'verificationId' nor one of these options is specified, the verification id is looked up in the trust store.
+  An id list read with '--id-url' contains lines with a context id and a verification id separated by white space.
   All the files in the signatures file will be verified.
+  If the '--archive' option is specified, the files are read from the archive instead of the current directory.
+  If the archive contains the signatures file, this embedded signatures file is used and every other file in the archive must be signed.
+  If the '--stdin-data' option is specified, only the data from stdin are verified against the signature with the name in the '--as' option.
+  If the '--revocations' option is specified, the verification id must not be in the revocation list with the list id in the '--revocations-id' option.
+  A revoked verification id results in return code 4.
+  The verification fails if the signatures have expired at the time in the '--at' option or the current time.
+  If the '--notify-url' option is specified, a summary of a failed verification is posted as JSON to this URL.
+  The summary is signed with HMAC-SHA256 with the key in the environment variable in the '--notify-key-env' option.
+  If the '--metrics-file' option is specified, the metrics of the verification are written to this file in the Prometheus text format.
+  The '--name' option may be specified more than once to verify more than one signatures file in the current directory.
+  If the '--discover' option is specified, all signatures files in the current directory and its subdirectories are verified.
+  The files of each signatures file are verified relative to the directory of the signatures file.
+  More than one signatures file can only be verified with verification ids from the '--id-url' option or the trust store.
+  Then a combined summary is printed and the return code is the worst return code of all signatures files.
+
+
+Watch files:
+`)
+	_, _ = fmt.Printf(`  %s watch [verificationId] [flags]`, myName)
+	_, _ = fmt.Print(`
+
+  with 'flags' being one or more of the following options:
+
+`)
+	wcl.PrintUsage()
+	_, _ = fmt.Print(`
+  All the files in the signatures file are verified and then watched. A file is verified again as soon as it has been changed.
+  All files are verified again after each interval in the '--interval' option and after the signatures file has been changed.
+  The verification id is specified as for the 'verify' command.
+  Watching ends when the signatures file can not be verified or the program is interrupted.
+  The return code is the worst return code of all verifications while watching.
+  Watching is only supported on Linux.
+
+
+Manage trusted verification ids:
+`)
+	_, _ = fmt.Printf(`  %s trust add {verificationId} --label {label} [flags]
+  %s trust list [flags]
+  %s trust remove {verificationId|label} [flags]`, myName, myName, myName)
+	_, _ = fmt.Print(`
+
+  with 'flags' being one or more of the following options:
+
+`)
+	tcl.PrintUsage()
+	_, _ = fmt.Print(`
+  The trust store is protected by a key that is stored in a file with the same name and the additional extension '.key'.
+
+
+Revoke a verification id:
+`)
+	_, _ = fmt.Printf(`  %s revoke [verificationId --reason {reason}] --list {file} [--valid-for {duration}]`, myName)
+	_, _ = fmt.Print(`
+
+  with the following options:
+
+`)
+	rcl.PrintUsage()
+	_, _ = fmt.Print(`
+  The verification id is added to the revocation list, which is created if it does not exist.
+  The revocation list is signed with the key in a file with the same name and the additional extension '.key',
+  which is created with the list. Without a verification id the list is only signed again, so that it does not expire.
+
+
+Verify a signature log:
+`)
+	_, _ = fmt.Printf(`  %s log verify [verificationId] --file {file}`, myName)
+	_, _ = fmt.Print(`
+
+  with the following option:
+
+`)
+	lcl.PrintUsage()
+	_, _ = fmt.Print(`
+  The hash chain of the signature log is checked.
+  If a 'verificationId' is specified, the entries of the signature log with this verification id are printed.
+
+
+Check the integrity of this program:
+`)
+	_, _ = fmt.Printf(`  %s selfcheck [verificationId] [flags]`, myName)
+	_, _ = fmt.Print(`
+
+  with 'flags' being one or more of the following options:
+
+`)
+	sccl.PrintUsage()
+	_, _ = fmt.Print(`
+  The running executable is verified against its entry in the published signatures file.
+  The verification id is either the 'verificationId', read from the id list in the '--id-url' option or read from the id list that has been compiled into the program.
 
 
 Get version:
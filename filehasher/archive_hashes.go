@@ -0,0 +1,128 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.4.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Write files into an archive while hashing them.
+//    2026-10-19: V1.2.0: Use hash options.
+//    2026-10-19: V1.3.0: Read files from the file system of the hash options.
+//    2026-10-19: V1.4.0: Count hashed bytes of archive entries.
+//
+
+package filehasher
+
+import (
+	"filesigner/archive"
+	"filesigner/filehelper"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"slices"
+)
+
+// ******** Public functions ********
+
+// ArchiveHashes computes the hashes of all regular files in an archive.
+// The entries of an archive can only be read one after the other,
+// so the hashes are computed sequentially while streaming through the archive.
+// The keys of the result are the archive-internal paths with platform-specific separators.
+func ArchiveHashes(archivePath string, options *HashOptions) (map[string]*HashResult, error) {
+	result := make(map[string]*HashResult)
+
+	err := archive.ForEachEntry(archivePath, func(entryPath string, r io.Reader) error {
+		fileHasher, err := newFileHasher(options)
+		if err != nil {
+			return err
+		}
+
+		hashResult := &HashResult{FilePath: filepath.FromSlash(entryPath)}
+		fileHasher.hashInto(hashResult, r)
+		if hashResult.Err != nil {
+			return fmt.Errorf(`Could not read archive entry '%s': %w`, entryPath, hashResult.Err)
+		}
+
+		result[hashResult.FilePath] = hashResult
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FileHashesIntoArchive computes the hashes of the supplied files and writes them into an archive.
+// Entries can only be written one after the other, so the files are processed sequentially in sorted order.
+// Each file is read only once, so the hash value is always computed over the content that is in the archive.
+// Any error is fatal, as the archive can not be used any more.
+func FileHashesIntoArchive(filePaths []string, options *HashOptions, archiveWriter *archive.Writer) (map[string]*HashResult, error) {
+	result := make(map[string]*HashResult, len(filePaths))
+
+	sortedPaths := slices.Clone(filePaths)
+	slices.Sort(sortedPaths)
+
+	for _, filePath := range sortedPaths {
+		hashValue, err := hashFileIntoArchive(filePath, options, archiveWriter)
+		if err != nil {
+			return nil, fmt.Errorf(`Could not write file '%s' into archive: %w`, filePath, err)
+		}
+
+		result[filePath] = &HashResult{FilePath: filePath, HashValue: hashValue}
+	}
+
+	return result, nil
+}
+
+// ******** Private functions ********
+
+// hashFileIntoArchive calculates the hash value of a file and writes its content into an archive entry.
+func hashFileIntoArchive(filePath string, options *HashOptions, archiveWriter *archive.Writer) ([]byte, error) {
+	f, err := options.openFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer filehelper.CloseFSFile(f, filePath)
+
+	var fileInfo fs.FileInfo
+	fileInfo, err = f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var entryWriter io.Writer
+	entryWriter, err = archiveWriter.CreateEntry(filepath.ToSlash(filePath), fileInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileHasher *fileHasher
+	fileHasher, err = newFileHasher(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return fileHasher.hashReader(io.TeeReader(f, entryWriter))
+}
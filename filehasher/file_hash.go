@@ -20,69 +20,220 @@
 //
 // Author: Frank Schwab
 //
-// Version: 2.0.0
+// Version: 2.6.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
 //    2026-08-20: V2.0.0: Only private functions; use "crypto/sha3".
+//    2026-10-19: V2.1.0: Hash content of readers.
+//    2026-10-19: V2.2.0: Use hash options and support Merkle tree hashing.
+//    2026-10-19: V2.3.0: Get metadata of files.
+//    2026-10-19: V2.4.0: Do not follow symbolic links, if requested.
+//    2026-10-19: V2.5.0: Read files from a file system.
+//    2026-10-19: V2.6.0: Count hashed bytes.
 //
 
 package filehasher
 
 import (
 	"crypto/sha3"
+	"errors"
 	"filesigner/filehelper"
+	"filesigner/filemetadata"
 	"filesigner/numberhelper"
 	"filesigner/paddedhasher"
 	"hash"
-
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // ******** Public types ********
 
+// HashOptions contains the parameters of the hash calculation.
+type HashOptions struct {
+	// ContextKey is the key that makes the hash values depend on the context id.
+	ContextKey []byte
+
+	// ChunkSize is the size of the chunks of a Merkle tree.
+	// If it is 0, the content of a file is hashed as one stream.
+	ChunkSize int64
+
+	// WithMetadata specifies that the metadata of files are returned together with their hash values.
+	WithMetadata bool
+
+	// NoFollow specifies that symbolic links are not followed.
+	// Then a symbolic link is an error, unless RecordLinks is set.
+	NoFollow bool
+
+	// RecordLinks specifies that the target of a symbolic link is hashed instead of the content it points to.
+	// It is only used if NoFollow is set.
+	RecordLinks bool
+
+	// FS is the file system that the files are read from. The file paths are relative to its root.
+	// If it is nil, the files are read from the operating system's file system.
+	FS fs.FS
+}
+
+// ******** Public variables ********
+
+// ErrSymlink is returned if a file is a symbolic link that must not be followed.
+var ErrSymlink = errors.New(`File is a symbolic link`)
+
 type fileHasher struct {
-	hasher *paddedhasher.PaddedHasher
+	options *HashOptions
+}
+
+// countingReader is a reader that counts the bytes read from another reader.
+type countingReader struct {
+	r     io.Reader
+	count int64
 }
 
 // ******** Creation functions ********
 
 // newFileHasher Create a new file hasher structure.
-func newFileHasher(contextKey []byte) (*fileHasher, error) {
-	hasher := paddedhasher.NewPaddedHasher(
-		hash.Hash(sha3.New512()),
-		contextKey,
-	)
-
-	return &fileHasher{hasher}, nil
+func newFileHasher(options *HashOptions) (*fileHasher, error) {
+	return &fileHasher{options}, nil
 }
 
 // ******** Private functions ********
 
-// hashFile calculates the hash value for one file.
-func (fh *fileHasher) hashFile(filePath string) ([]byte, error) {
-	hasher := fh.hasher
+// hashFile calculates the hash value for the file in the hash result and stores it there.
+// The metadata of the file are only stored, if they are requested in the hash options.
+func (fh *fileHasher) hashFile(result *HashResult) {
+	if fh.options.NoFollow {
+		fi, err := fh.options.lstat(result.FilePath)
+		if err != nil {
+			result.Err = err
+			return
+		}
+
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			fh.hashLink(result, fi)
+			return
+		}
+	}
 
-	err := hashFileContent(hasher, filePath)
+	f, err := fh.options.openFile(result.FilePath)
 	if err != nil {
-		return nil, err
+		result.Err = err
+		return
 	}
+	defer filehelper.CloseFSFile(f, result.FilePath)
 
-	return hasher.Sum(nil), nil
+	if fh.options.WithMetadata {
+		var fi fs.FileInfo
+		fi, err = f.Stat()
+		if err != nil {
+			result.Err = err
+			return
+		}
+
+		result.Metadata = filemetadata.FromFileInfo(fi)
+	}
+
+	fh.hashInto(result, f)
 }
 
-// hashFileContent writes the content of a file to a hasher.
-func hashFileContent(hasher *paddedhasher.PaddedHasher, filePath string) error {
-	var err error
-	var f *os.File
-	f, err = os.Open(filePath)
+// hashLink calculates the hash value of the target of a symbolic link and stores it in the hash result.
+func (fh *fileHasher) hashLink(result *HashResult, fi fs.FileInfo) {
+	if !fh.options.RecordLinks {
+		result.Err = ErrSymlink
+		return
+	}
+
+	target, err := fh.options.readLink(result.FilePath)
 	if err != nil {
-		return err
+		result.Err = err
+		return
+	}
+
+	if fh.options.WithMetadata {
+		result.Metadata = filemetadata.FromFileInfo(fi)
+	}
+
+	result.LinkTarget = target
+	fh.hashInto(result, strings.NewReader(target))
+}
+
+// openFile opens a file for reading.
+// If NoFollow is set, the file is not opened if it is a symbolic link, on platforms that support this.
+// A file system whose files are not in the operating system's file system does not need this check.
+func (options *HashOptions) openFile(filePath string) (fs.File, error) {
+	if options.FS != nil {
+		return options.FS.Open(filepath.ToSlash(filePath))
 	}
-	defer filehelper.CloseFile(f)
 
-	_, err = io.Copy(hasher, f)
+	if options.NoFollow {
+		return os.OpenFile(filePath, os.O_RDONLY|noFollowFlag, 0)
+	}
+
+	return os.Open(filePath)
+}
+
+// lstat returns the file info of a file without following a symbolic link.
+// If the file system does not support symbolic links, the file info of the file is returned.
+func (options *HashOptions) lstat(filePath string) (fs.FileInfo, error) {
+	if options.FS != nil {
+		return fs.Lstat(options.FS, filepath.ToSlash(filePath))
+	}
+
+	return os.Lstat(filePath)
+}
+
+// readLink returns the target of a symbolic link.
+func (options *HashOptions) readLink(filePath string) (string, error) {
+	if options.FS != nil {
+		return fs.ReadLink(options.FS, filepath.ToSlash(filePath))
+	}
+
+	return os.Readlink(filePath)
+}
+
+// hashInto calculates the hash value for the content of a reader and stores it and the number of hashed bytes in the hash result.
+func (fh *fileHasher) hashInto(result *HashResult, r io.Reader) {
+	cr := &countingReader{r: r}
+	result.HashValue, result.Err = fh.hashReader(cr)
+	result.ByteCount = cr.count
+}
+
+// Read reads from the underlying reader and counts the bytes read.
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.count += int64(n)
+	return n, err
+}
+
+// hashReader calculates the hash value for the content of a reader.
+func (fh *fileHasher) hashReader(r io.Reader) ([]byte, error) {
+	if fh.options.ChunkSize != 0 {
+		return merkleRootOfReader(r, fh.options.ContextKey, fh.options.ChunkSize)
+	}
+
+	hasher := newContextHasher(fh.options.ContextKey)
+
+	err := hashReaderContent(hasher, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// newContextHasher creates a new hasher that is padded with the context key.
+func newContextHasher(contextKey []byte) *paddedhasher.PaddedHasher {
+	return paddedhasher.NewPaddedHasher(
+		hash.Hash(sha3.New512()),
+		contextKey,
+	)
+}
+
+// hashReaderContent writes the content of a reader to a hasher.
+func hashReaderContent(hasher *paddedhasher.PaddedHasher, r io.Reader) error {
+	_, err := io.Copy(hasher, r)
 	if err != nil {
 		return err
 	}
@@ -20,16 +20,21 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.1.0
+// Version: 1.5.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
 //    2024-02-17: V1.1.0: Use contextBytes.
+//    2026-10-19: V1.2.0: Use hash options.
+//    2026-10-19: V1.3.0: Add metadata to hash result.
+//    2026-10-19: V1.4.0: Add link target to hash result.
+//    2026-10-19: V1.5.0: Add byte count to hash result.
 //
 
 package filehasher
 
 import (
+	"filesigner/filemetadata"
 	"runtime"
 	"sync"
 )
@@ -39,13 +44,18 @@ import (
 type HashResult struct {
 	FilePath  string
 	HashValue []byte
+	Metadata  *filemetadata.Metadata
+	// LinkTarget is the target of a symbolic link, if the link has been hashed instead of the content it points to.
+	LinkTarget string
+	// ByteCount is the number of bytes that have been hashed.
+	ByteCount int64
 	Err       error
 }
 
 // ******** Public functions ********
 
 // FileHashes computes the hashes of the supplied files in an asynchronous manner.
-func FileHashes(filePaths []string, contextKey []byte) map[string]*HashResult {
+func FileHashes(filePaths []string, options *HashOptions) map[string]*HashResult {
 	// hasherWaitGroup is used to wait for all hashers to finish
 	var hasherWaitGroup sync.WaitGroup
 
@@ -53,7 +63,7 @@ func FileHashes(filePaths []string, contextKey []byte) map[string]*HashResult {
 	hasherResultChannel := make(chan *HashResult, runtime.NumCPU())
 
 	// Start an asynchronous hasher for each file to hash.
-	numHashes := startFileHashers(filePaths, contextKey, &hasherWaitGroup, &hasherResultChannel)
+	numHashes := startFileHashers(filePaths, options, &hasherWaitGroup, &hasherResultChannel)
 
 	// Start an asynchronous function that waits for all hashers to finish and then close the hasherResultChannel.
 	go waitForAllHashers(&hasherWaitGroup, &hasherResultChannel)
@@ -66,7 +76,7 @@ func FileHashes(filePaths []string, contextKey []byte) map[string]*HashResult {
 
 // startFileHashers starts the file hasher processes asynchronously.
 func startFileHashers(filePaths []string,
-	contextKey []byte,
+	options *HashOptions,
 	hasherWaitGroup *sync.WaitGroup,
 	hasherResultChannel *chan *HashResult) int {
 	numHashes := 0
@@ -74,7 +84,7 @@ func startFileHashers(filePaths []string,
 	for _, aFilePath := range filePaths {
 		numHashes++
 		hasherWaitGroup.Add(1) // This must be done before the start of the goroutine, so that the waiter will have to wait for the first goroutine to start.
-		go fileHashWorker(aFilePath, contextKey, hasherWaitGroup, hasherResultChannel)
+		go fileHashWorker(aFilePath, options, hasherWaitGroup, hasherResultChannel)
 	}
 
 	return numHashes
@@ -111,16 +121,16 @@ func waitForAllHashers(hasherWaitGroup *sync.WaitGroup, hasherResultChannel *cha
 
 // fileHashWorker calculates the hash value of one file.
 func fileHashWorker(filePath string,
-	contextKey []byte,
+	options *HashOptions,
 	hasherWaitGroup *sync.WaitGroup,
 	hasherResultChannel *chan *HashResult) {
 	defer hasherWaitGroup.Done()
 
 	result := &HashResult{}
 	result.FilePath = filePath
-	fileHasher, err := newFileHasher(contextKey)
+	fileHasher, err := newFileHasher(options)
 	if err == nil {
-		result.HashValue, result.Err = fileHasher.hashFile(filePath)
+		fileHasher.hashFile(result)
 	} else {
 		result.HashValue = nil
 		result.Err = err
@@ -0,0 +1,257 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Limit the memory of the chunks instead of their number.
+//
+
+package filehasher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// ******** Public constants ********
+
+// MinChunkSize is the smallest chunk size of a Merkle tree.
+const MinChunkSize int64 = 4 << 10
+
+// MaxChunkSize is the largest chunk size of a Merkle tree.
+const MaxChunkSize int64 = 1 << 30
+
+// DefaultChunkSize is the chunk size of a Merkle tree if none is specified.
+const DefaultChunkSize int64 = 4 << 20
+
+// ******** Private constants ********
+
+// These are the prefixes that separate the hash values of leaves and nodes of a Merkle tree.
+const (
+	merkleLeafPrefix byte = 0
+	merkleNodePrefix byte = 1
+)
+
+// maxPooledChunkSize is the largest size of a chunk buffer that is kept for reuse.
+// Larger buffers are left to the garbage collector, so that they do not stay in memory.
+const maxPooledChunkSize = DefaultChunkSize
+
+// ******** Private types ********
+
+// memoryLimiter limits the number of bytes that are in use at the same time.
+// Requests are served in the order in which they arrive, so that a large request
+// is not starved by small ones.
+type memoryLimiter struct {
+	mutex   sync.Mutex
+	budget  int64
+	inUse   int64
+	waiters []*memoryWaiter
+}
+
+// memoryWaiter is a request that waits for memory.
+type memoryWaiter struct {
+	size  int64
+	ready chan struct{}
+}
+
+// ******** Private variables ********
+
+// chunkMemory limits the memory of the chunks that are in memory at the same time over all files.
+// Without this limit hashing many large files in parallel would need huge amounts of memory.
+// The budget allows two chunks of the default size per CPU. Larger chunks are hashed with less parallelism.
+var chunkMemory = newMemoryLimiter(2 * int64(runtime.NumCPU()) * DefaultChunkSize)
+
+// chunkBufferPool contains chunk buffers that can be reused.
+var chunkBufferPool sync.Pool
+
+// ******** Public functions ********
+
+// CheckChunkSize checks if a chunk size is a power of 2 between MinChunkSize and MaxChunkSize.
+func CheckChunkSize(chunkSize int64) error {
+	if chunkSize < MinChunkSize || chunkSize > MaxChunkSize || chunkSize&(chunkSize-1) != 0 {
+		return fmt.Errorf(`Chunk size %d is not a power of 2 between %d and %d`, chunkSize, MinChunkSize, MaxChunkSize)
+	}
+
+	return nil
+}
+
+// ******** Private functions ********
+
+// merkleRootOfReader calculates the root hash of a Merkle tree over the content of a reader.
+// The content is read sequentially and split into chunks of chunkSize bytes, only the last chunk may be shorter.
+// The chunks are hashed in parallel. Empty content consists of one empty chunk.
+// The tree has the same structure as the one in RFC 6962, so that ranges of a file can be verified.
+func merkleRootOfReader(r io.Reader, contextKey []byte, chunkSize int64) ([]byte, error) {
+	var leafWaitGroup sync.WaitGroup
+	var leafHashes []*[]byte
+
+	for {
+		chunkMemory.acquire(chunkSize)
+		chunk := getChunkBuffer(chunkSize)
+
+		n, err := io.ReadFull(r, *chunk)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			releaseChunk(chunk)
+			leafWaitGroup.Wait()
+			return nil, err
+		}
+
+		if n == 0 && len(leafHashes) != 0 {
+			releaseChunk(chunk)
+			break
+		}
+
+		leafHash := new([]byte)
+		leafHashes = append(leafHashes, leafHash)
+
+		leafWaitGroup.Add(1)
+		go leafHashWorker(leafHash, chunk, n, contextKey, &leafWaitGroup)
+
+		if int64(n) < chunkSize {
+			break
+		}
+	}
+
+	leafWaitGroup.Wait()
+
+	hashes := make([][]byte, len(leafHashes))
+	for i, leafHash := range leafHashes {
+		hashes[i] = *leafHash
+	}
+
+	return merkleTreeHash(hashes, contextKey), nil
+}
+
+// leafHashWorker calculates the hash value of the first n bytes of a chunk and releases the chunk.
+func leafHashWorker(leafHash *[]byte, chunk *[]byte, n int, contextKey []byte, leafWaitGroup *sync.WaitGroup) {
+	defer leafWaitGroup.Done()
+	defer releaseChunk(chunk)
+
+	*leafHash = merkleLeafHash((*chunk)[:n], contextKey)
+}
+
+// merkleLeafHash calculates the hash value of a leaf of a Merkle tree.
+func merkleLeafHash(data []byte, contextKey []byte) []byte {
+	hasher := newContextHasher(contextKey)
+
+	_, _ = hasher.Write([]byte{merkleLeafPrefix})
+	_ = hashReaderContent(hasher, bytes.NewReader(data))
+
+	return hasher.Sum(nil)
+}
+
+// merkleTreeHash calculates the root hash of a Merkle tree from the hash values of its leaves.
+// The left subtree always contains the largest power of 2 that is smaller than the number of leaves.
+func merkleTreeHash(hashes [][]byte, contextKey []byte) []byte {
+	n := len(hashes)
+	if n == 1 {
+		return hashes[0]
+	}
+
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+
+	hasher := newContextHasher(contextKey)
+
+	_, _ = hasher.Write([]byte{merkleNodePrefix})
+	_, _ = hasher.Write(merkleTreeHash(hashes[:k], contextKey))
+	_, _ = hasher.Write(merkleTreeHash(hashes[k:], contextKey))
+
+	return hasher.Sum(nil)
+}
+
+// getChunkBuffer gets a buffer for a chunk from the pool or creates a new one.
+func getChunkBuffer(chunkSize int64) *[]byte {
+	buffer, ok := chunkBufferPool.Get().(*[]byte)
+	if !ok || int64(cap(*buffer)) < chunkSize {
+		newBuffer := make([]byte, chunkSize)
+		return &newBuffer
+	}
+
+	*buffer = (*buffer)[:chunkSize]
+
+	return buffer
+}
+
+// releaseChunk puts a chunk buffer back into the pool, if it is not too large, and frees its memory.
+func releaseChunk(chunk *[]byte) {
+	chunkSize := int64(len(*chunk))
+	if int64(cap(*chunk)) <= maxPooledChunkSize {
+		chunkBufferPool.Put(chunk)
+	}
+
+	chunkMemory.release(chunkSize)
+}
+
+// newMemoryLimiter creates a memory limiter with a budget of bytes.
+func newMemoryLimiter(budget int64) *memoryLimiter {
+	return &memoryLimiter{budget: budget}
+}
+
+// acquire waits until size bytes are available and marks them as used.
+// A size that is larger than the budget is reduced to the budget,
+// so that it can be used when nothing else is in use.
+func (m *memoryLimiter) acquire(size int64) {
+	size = min(size, m.budget)
+
+	m.mutex.Lock()
+	if len(m.waiters) == 0 && m.inUse+size <= m.budget {
+		m.inUse += size
+		m.mutex.Unlock()
+		return
+	}
+
+	w := &memoryWaiter{size: size, ready: make(chan struct{})}
+	m.waiters = append(m.waiters, w)
+	m.mutex.Unlock()
+
+	<-w.ready
+}
+
+// release frees size bytes that have been acquired and serves the waiting requests that fit into the budget.
+func (m *memoryLimiter) release(size int64) {
+	size = min(size, m.budget)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.inUse -= size
+
+	for len(m.waiters) != 0 {
+		w := m.waiters[0]
+		if m.inUse+w.size > m.budget {
+			break
+		}
+
+		m.inUse += w.size
+		m.waiters = m.waiters[1:]
+		close(w.ready)
+	}
+}
@@ -0,0 +1,195 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Memory limiter.
+//
+
+package filehasher
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// ******** Private variables ********
+
+// testContextKey is the context key for the tests.
+var testContextKey = []byte(`TestContext`)
+
+// ******** Test functions ********
+
+func TestMerkleSingleChunk(t *testing.T) {
+	data := []byte(`Short content`)
+
+	checkMerkleRoot(t, data, 64, merkleLeafHash(data, testContextKey))
+}
+
+func TestMerkleEmpty(t *testing.T) {
+	checkMerkleRoot(t, nil, 64, merkleLeafHash(nil, testContextKey))
+}
+
+func TestMerkleTree(t *testing.T) {
+	data := bytes.Repeat([]byte(`0123456789`), 25)
+
+	// 250 bytes are 3 full chunks and one partial chunk of 64 bytes.
+	h0 := merkleLeafHash(data[0:64], testContextKey)
+	h1 := merkleLeafHash(data[64:128], testContextKey)
+	h2 := merkleLeafHash(data[128:192], testContextKey)
+	h3 := merkleLeafHash(data[192:], testContextKey)
+
+	expected := makeNodeHash(makeNodeHash(h0, h1), makeNodeHash(h2, h3))
+	checkMerkleRoot(t, data, 64, expected)
+
+	// 5 leaves are split into a subtree of 4 leaves and a single leaf.
+	data = append(data, bytes.Repeat([]byte(`x`), 70)...)
+	h3 = merkleLeafHash(data[192:256], testContextKey)
+	h4 := merkleLeafHash(data[256:], testContextKey)
+
+	expected = makeNodeHash(makeNodeHash(makeNodeHash(h0, h1), makeNodeHash(h2, h3)), h4)
+	checkMerkleRoot(t, data, 64, expected)
+}
+
+func TestMerkleFullChunks(t *testing.T) {
+	data := bytes.Repeat([]byte(`a`), 128)
+
+	expected := makeNodeHash(merkleLeafHash(data[:64], testContextKey), merkleLeafHash(data[64:], testContextKey))
+	checkMerkleRoot(t, data, 64, expected)
+}
+
+func TestMerkleDiffersFromStream(t *testing.T) {
+	data := []byte(`Some content`)
+
+	streamHasher, _ := newFileHasher(&HashOptions{ContextKey: testContextKey})
+	streamHash, err := streamHasher.hashReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf(`Stream hash failed: %v`, err)
+	}
+
+	merkleHasher, _ := newFileHasher(&HashOptions{ContextKey: testContextKey, ChunkSize: MinChunkSize})
+	var merkleHash []byte
+	merkleHash, err = merkleHasher.hashReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf(`Merkle hash failed: %v`, err)
+	}
+
+	if bytes.Equal(streamHash, merkleHash) {
+		t.Fatal(`Stream hash and Merkle hash are equal`)
+	}
+}
+
+func TestCheckChunkSize(t *testing.T) {
+	for _, chunkSize := range []int64{MinChunkSize, DefaultChunkSize, MaxChunkSize} {
+		if CheckChunkSize(chunkSize) != nil {
+			t.Fatalf(`Valid chunk size %d not accepted`, chunkSize)
+		}
+	}
+
+	for _, chunkSize := range []int64{0, MinChunkSize >> 1, MaxChunkSize << 1, DefaultChunkSize + 1} {
+		if CheckChunkSize(chunkSize) == nil {
+			t.Fatalf(`Invalid chunk size %d accepted`, chunkSize)
+		}
+	}
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	m := newMemoryLimiter(100)
+
+	m.acquire(60)
+
+	// The large request has to wait and the small one must not overtake it.
+	large := acquireInBackground(m, 1000)
+	checkWaiting(t, large, `Large request`)
+
+	small := acquireInBackground(m, 10)
+	checkWaiting(t, small, `Small request`)
+
+	// The large request is reduced to the budget and gets it, when nothing else is in use.
+	m.release(60)
+	checkServed(t, large, `Large request`)
+	checkWaiting(t, small, `Small request`)
+
+	m.release(1000)
+	checkServed(t, small, `Small request`)
+
+	m.release(10)
+	if m.inUse != 0 {
+		t.Fatalf(`%d bytes in use after all have been released`, m.inUse)
+	}
+}
+
+// ******** Private functions ********
+
+// acquireInBackground acquires memory in a goroutine and returns a channel that is closed when it has been acquired.
+func acquireInBackground(m *memoryLimiter, size int64) chan struct{} {
+	result := make(chan struct{})
+	go func() {
+		m.acquire(size)
+		close(result)
+	}()
+
+	return result
+}
+
+// checkWaiting checks that a request is still waiting.
+func checkWaiting(t *testing.T, done chan struct{}, name string) {
+	select {
+	case <-done:
+		t.Fatalf(`%s has not waited`, name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// checkServed checks that a request has been served.
+func checkServed(t *testing.T, done chan struct{}, name string) {
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf(`%s has not been served`, name)
+	}
+}
+
+// checkMerkleRoot checks that the Merkle root of the data is the expected value.
+func checkMerkleRoot(t *testing.T, data []byte, chunkSize int64, expected []byte) {
+	root, err := merkleRootOfReader(bytes.NewReader(data), testContextKey, chunkSize)
+	if err != nil {
+		t.Fatalf(`Merkle hash failed: %v`, err)
+	}
+
+	if !bytes.Equal(root, expected) {
+		t.Fatalf(`Wrong Merkle root for %d bytes`, len(data))
+	}
+}
+
+// makeNodeHash calculates the hash value of a node from the hash values of its children.
+func makeNodeHash(left []byte, right []byte) []byte {
+	hasher := newContextHasher(testContextKey)
+	_, _ = hasher.Write([]byte{merkleNodePrefix})
+	_, _ = hasher.Write(left)
+	_, _ = hasher.Write(right)
+
+	return hasher.Sum(nil)
+}
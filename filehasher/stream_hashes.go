@@ -0,0 +1,51 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Count hashed bytes.
+//
+
+package filehasher
+
+import (
+	"io"
+)
+
+// ******** Public functions ********
+
+// StreamHashes computes the hash of the content of a reader and records it under the given file path.
+// The result has the same form as the result of FileHashes, so that it can be signed and verified like files.
+func StreamHashes(filePath string, r io.Reader, options *HashOptions) map[string]*HashResult {
+	result := &HashResult{FilePath: filePath}
+
+	fileHasher, err := newFileHasher(options)
+	if err == nil {
+		fileHasher.hashInto(result, r)
+	} else {
+		result.Err = err
+	}
+
+	return map[string]*HashResult{filePath: result}
+}
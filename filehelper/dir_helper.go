@@ -20,10 +20,11 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.1.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Honor ignore files.
 //
 
 package filehelper
@@ -34,6 +35,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 )
 
 // ******** Public functions ********
@@ -77,6 +79,7 @@ func SplitPath(path string) (parts []string) {
 }
 
 // PathGlob returns all globbed files from a path that may contain wildcards.
+// Files inside the current directory that are ignored by ignore files are not returned.
 func PathGlob(path string, excludeDirList []string, excludeFileList []string) ([]string, error) {
 	// An empty path returns nil
 	if len(path) == 0 {
@@ -122,7 +125,7 @@ func PathGlob(path string, excludeDirList []string, excludeFileList []string) ([
 		}
 	}
 
-	return fullPaths, nil
+	return removeIgnoredPaths(fullPaths)
 }
 
 // EnsureDriveLetterIsUpperCase ensures that the drive letter is an upper-case letter.
@@ -160,6 +163,39 @@ func isNameExcluded(name string, findDirs bool, excludeDirList []string, exclude
 	return isExcluded, err
 }
 
+// removeIgnoredPaths removes the paths inside the current directory that are ignored by ignore files.
+func removeIgnoredPaths(paths []string) ([]string, error) {
+	thisDirPath, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	EnsureDriveLetterIsUpperCase(thisDirPath)
+
+	result := make([]string, 0, len(paths))
+	for _, aPath := range paths {
+		var relPath string
+		relPath, err = filepath.Rel(thisDirPath, aPath)
+		if err != nil || relPath == `..` || strings.HasPrefix(relPath, `..`+string(filepath.Separator)) {
+			// Paths outside the current directory are not affected by ignore files.
+			result = append(result, aPath)
+			continue
+		}
+
+		var isIgnored bool
+		isIgnored, err = isPathIgnored(relPath, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isIgnored {
+			result = append(result, aPath)
+		}
+	}
+
+	return result, nil
+}
+
 // walkThroughPart loops through each element of full path, adds part to it and returns all file names
 // that match the resulting specification. If findDirs is true, it will search for directories,
 // otherwise it will search for files.
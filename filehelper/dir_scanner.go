@@ -20,49 +20,128 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 2.0.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Respect .gitignore files.
+//    2026-10-19: V1.2.0: Match path patterns.
+//    2026-10-19: V1.3.0: Honor ignore files.
+//    2026-10-19: V1.4.0: Scan file systems.
+//    2026-10-19: V2.0.0: Keep the state of a scan in a scanner, so that scans can run concurrently.
 //
 
 package filehelper
 
 import (
+	"filesigner/gitignore"
 	"filesigner/set"
 	"io/fs"
 	"path/filepath"
 )
 
-// ******** Private variables ********
+// ******** Private types ********
 
-var modExcludeFileNameList []string
-var modIncludeFileNameList []string
-var modExcludeDirNameList []string
-var modIncludeDirNameList []string
-var modDoRecursion bool
-var modResultList *set.Set[string]
+// dirScanner contains the state of a scan of a file system.
+// Each scan has its own scanner, so that scans can run concurrently.
+type dirScanner struct {
+	// fileSystem is the file system that is scanned. It is nil, if the current directory is scanned.
+	fileSystem fs.FS
+	// ignoreFiles contains the ignore files of the file system that is scanned.
+	ignoreFiles         *ignoreFileCache
+	excludeFileNameList []string
+	includeFileNameList []string
+	excludeDirNameList  []string
+	includeDirNameList  []string
+	doRecursion         bool
+	resultList          *set.Set[string]
+	gitignoreMatcher    *gitignore.Matcher
+}
+
+// ******** Public functions ********
 
+// ScanDir returns the files in the current directory and, if doRecursion is set, in its subdirectories
+// that match the include and exclude lists.
+// Files and directories that are ignored by ignore files are skipped.
+// If respectGitignore is set, files and directories that are ignored by ".gitignore" files are skipped, as well.
 func ScanDir(includeFileList []string,
 	excludeFileList []string,
 	includeDirList []string,
 	excludeDirList []string,
-	doRecursion bool) (*set.Set[string], error) {
-	// We need to copy all the parameters to private variables,
-	// as WalkDir can not pass them to our WalkEntryFunction.
-	modIncludeFileNameList = includeFileList
-	modExcludeFileNameList = excludeFileList
-	modIncludeDirNameList = includeDirList
-	modExcludeDirNameList = excludeDirList
-	modDoRecursion = doRecursion
-	modResultList = set.New[string]()
-
-	// Always walk the current directory
-	return modResultList, filepath.WalkDir(".", WalkEntryFunction)
+	doRecursion bool,
+	respectGitignore bool) (*set.Set[string], error) {
+	return scan(nil,
+		modIgnoreFiles,
+		includeFileList,
+		excludeFileList,
+		includeDirList,
+		excludeDirList,
+		doRecursion,
+		respectGitignore)
+}
+
+// ScanFS returns the files in the root directory of a file system and, if doRecursion is set, in its subdirectories
+// that match the include and exclude lists. The returned paths are slash-separated and relative to the root.
+// Files and directories that are ignored by the ignore files in the file system are skipped.
+// If respectGitignore is set, files and directories that are ignored by ".gitignore" files are skipped, as well.
+func ScanFS(fsys fs.FS,
+	includeFileList []string,
+	excludeFileList []string,
+	includeDirList []string,
+	excludeDirList []string,
+	doRecursion bool,
+	respectGitignore bool) (*set.Set[string], error) {
+	return scan(fsys,
+		newIgnoreFileCache(),
+		includeFileList,
+		excludeFileList,
+		includeDirList,
+		excludeDirList,
+		doRecursion,
+		respectGitignore)
+}
+
+// ******** Private functions ********
+
+// scan walks through a file system, or the current directory if it is nil, and returns the files
+// that should be processed.
+func scan(fsys fs.FS,
+	ignoreFiles *ignoreFileCache,
+	includeFileList []string,
+	excludeFileList []string,
+	includeDirList []string,
+	excludeDirList []string,
+	doRecursion bool,
+	respectGitignore bool) (*set.Set[string], error) {
+	s := &dirScanner{
+		fileSystem:          fsys,
+		ignoreFiles:         ignoreFiles,
+		includeFileNameList: includeFileList,
+		excludeFileNameList: excludeFileList,
+		includeDirNameList:  includeDirList,
+		excludeDirNameList:  excludeDirList,
+		doRecursion:         doRecursion,
+		resultList:          set.New[string](),
+	}
+
+	if respectGitignore {
+		s.gitignoreMatcher = gitignore.NewMatcher()
+		err := s.addGitignoreDir(`.`)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Always walk the root directory
+	if fsys == nil {
+		return s.resultList, filepath.WalkDir(`.`, s.walkEntry)
+	}
+
+	return s.resultList, fs.WalkDir(fsys, `.`, s.walkEntry)
 }
 
-// WalkEntryFunction is called by filepath.WalkDir for each directory entry.
-func WalkEntryFunction(path string, dirEntry fs.DirEntry, dirErr error) error {
+// walkEntry is called by filepath.WalkDir or fs.WalkDir for each directory entry.
+func (s *dirScanner) walkEntry(path string, dirEntry fs.DirEntry, dirErr error) error {
 	// Return immediately if walking the directory tree returned an error.
 	if dirErr != nil {
 		return dirErr
@@ -77,7 +156,7 @@ func WalkEntryFunction(path string, dirEntry fs.DirEntry, dirErr error) error {
 	isDir := dirEntry.IsDir()
 
 	// If the entry is a directory and subdirectories are not allowed return SkipDir.
-	if isDir && !modDoRecursion {
+	if isDir && !s.doRecursion {
 		return filepath.SkipDir
 	}
 
@@ -91,18 +170,40 @@ func WalkEntryFunction(path string, dirEntry fs.DirEntry, dirErr error) error {
 	// the entry type.
 	if !isDir {
 		shouldProcess, err = shouldProcessEntry(entryName,
-			modIncludeFileNameList,
-			modExcludeFileNameList)
+			path,
+			false,
+			s.includeFileNameList,
+			s.excludeFileNameList)
 	} else {
 		shouldProcess, err = shouldProcessEntry(entryName,
-			modIncludeDirNameList,
-			modExcludeDirNameList)
+			path,
+			true,
+			s.includeDirNameList,
+			s.excludeDirNameList)
 	}
 
 	if err != nil {
 		return err
 	}
 
+	// The ignore files and the .gitignore files are applied on top of the include and exclude lists.
+	if shouldProcess {
+		var isIgnored bool
+		isIgnored, err = isEntryIgnored(s.fileSystem, s.ignoreFiles, path, isDir)
+		if err != nil {
+			return err
+		}
+
+		shouldProcess = !isIgnored
+	}
+
+	if shouldProcess && s.gitignoreMatcher != nil {
+		shouldProcess, err = s.shouldProcessGitignored(path, isDir)
+		if err != nil {
+			return err
+		}
+	}
+
 	// If this entry should not be processed the return value is nil
 	// if it is a file and SkipDir, if it is a directory.
 	if !shouldProcess {
@@ -117,26 +218,50 @@ func WalkEntryFunction(path string, dirEntry fs.DirEntry, dirErr error) error {
 	// If there are includes the entry also has to match an include specification.
 	// Only add files, not directories.
 	if !isDir {
-		modResultList.Add(path)
+		s.resultList.Add(path)
 	}
 
 	return nil
 }
 
+// addGitignoreDir adds the ".gitignore" file of a directory of the scanned file system to the gitignore matcher.
+func (s *dirScanner) addGitignoreDir(dirPath string) error {
+	if s.fileSystem == nil {
+		return s.gitignoreMatcher.AddDir(dirPath)
+	}
+
+	return s.gitignoreMatcher.AddFSDir(s.fileSystem, dirPath)
+}
+
+// shouldProcessGitignored returns "true" if the entry is not ignored by a .gitignore file, "false" otherwise.
+// The .gitignore file of a directory that is processed is read, so that it applies to the entries of the directory.
+func (s *dirScanner) shouldProcessGitignored(path string, isDir bool) (bool, error) {
+	if s.gitignoreMatcher.IsIgnored(path, isDir) {
+		return false, nil
+	}
+
+	if isDir {
+		return true, s.addGitignoreDir(path)
+	}
+
+	return true, nil
+}
+
 // shouldProcessEntry returns "true" if the entry is not excluded from processing, "false" otherwise.
-func shouldProcessEntry(entryName string, includeNames []string, excludeNames []string) (bool, error) {
+// A directory is included by a path pattern, if it may contain a path that matches the pattern.
+func shouldProcessEntry(entryName string, entryPath string, isDir bool, includeNames []string, excludeNames []string) (bool, error) {
 	var isEntryInList bool
 	var err error
 
 	if len(excludeNames) != 0 {
-		isEntryInList, err = MatchesAny(excludeNames, entryName)
+		isEntryInList, err = MatchesAnyEntry(excludeNames, entryName, entryPath, false)
 		if isEntryInList || err != nil {
 			return false, err
 		}
 	}
 
 	if len(includeNames) != 0 {
-		isEntryInList, err = MatchesAny(includeNames, entryName)
+		isEntryInList, err = MatchesAnyEntry(includeNames, entryName, entryPath, isDir)
 		if !isEntryInList || err != nil {
 			return false, err
 		}
@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,15 +20,19 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.2.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Add atomic writing of files.
+//    2026-10-19: V1.2.0: Add closing of files of a file system.
 //
 
 package filehelper
 
 import (
+	"errors"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
@@ -45,6 +49,14 @@ func CloseFile(file *os.File) {
 	}
 }
 
+// CloseFSFile closes a file of a file system and prints an error message if closing failed.
+func CloseFSFile(file fs.File, filePath string) {
+	err := file.Close()
+	if err != nil {
+		printFileOperationError(`clos`, filePath, err)
+	}
+}
+
 // DeleteFile deletes the file specified by the given file path.
 func DeleteFile(filePath string) {
 	err := os.Remove(filePath)
@@ -83,6 +95,35 @@ func IsFileName(filePath string) bool {
 	return filepath.Base(filePath) == filePath
 }
 
+// WriteFileAtomic writes data to a file so that the file either has the old or the new content.
+// The data are written to a temporary file in the same directory that is then renamed to the file path.
+func WriteFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), `.`+filepath.Base(filePath)+`-*.tmp`)
+	if err != nil {
+		return err
+	}
+
+	tempPath := tempFile.Name()
+
+	_, err = tempFile.Write(data)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	err = errors.Join(err, tempFile.Close())
+	if err == nil {
+		err = os.Chmod(tempPath, perm)
+	}
+	if err == nil {
+		err = os.Rename(tempPath, filePath)
+	}
+
+	if err != nil {
+		_ = os.Remove(tempPath)
+	}
+
+	return err
+}
+
 // ******** Private functions ********
 
 // printFileOperationError prints an error message for a file operation.
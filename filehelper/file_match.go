@@ -20,11 +20,12 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.2.0
 //
 // Change history:
 //    2024-02-03: V1.0.0: Created.
 //    2024-02-04: V1.1.0: Use cases.Fold.
+//    2026-10-19: V1.2.0: Can be used concurrently.
 //
 
 package filehelper
@@ -35,26 +36,21 @@ import (
 	"runtime"
 )
 
-var foldCaser = cases.Fold()
-
 // ******** Private variables ********
 
 // matcherMatchFunc is the pointer to the platform-dependent matcher function.
-var matcherMatchFunc func(string, string) (bool, error)
+// It is set when the package is initialized, so that it can be used concurrently.
+var matcherMatchFunc = newMatcherMatchFunc()
 
 // ******** Public functions ********
 
 // Matches returns true if the pattern matches the given name.
 func Matches(pattern string, name string) (bool, error) {
-	ensureMatcherIsInitialized()
-
 	return matcherMatchFunc(pattern, name)
 }
 
 // MatchesAny returns true if any pattern matches the given name.
 func MatchesAny(patterns []string, name string) (bool, error) {
-	ensureMatcherIsInitialized()
-
 	for _, entry := range patterns {
 		isMatch, err := matcherMatchFunc(entry, name)
 		if err != nil {
@@ -71,23 +67,18 @@ func MatchesAny(patterns []string, name string) (bool, error) {
 
 // ******** Private functions ********
 
-// ensureMatcherIsInitialized ensures, that the matcher is initialized.
-func ensureMatcherIsInitialized() {
-	if matcherMatchFunc == nil {
-		initMatcher()
-	}
-}
-
-// initMatcher initializes the platform-dependent match function of the matcher.
-func initMatcher() {
+// newMatcherMatchFunc returns the platform-dependent match function of the matcher.
+func newMatcherMatchFunc() func(string, string) (bool, error) {
 	if runtime.GOOS == `windows` {
-		matcherMatchFunc = caseInsensitiveMatchFunction
-	} else {
-		matcherMatchFunc = filepath.Match
+		return caseInsensitiveMatchFunction
 	}
+
+	return filepath.Match
 }
 
 // caseInsensitiveMatchFunction is a filepath.Match-equivalent function for case-insensitive file systems.
+// A caser can not be used concurrently, so each call uses its own one.
 func caseInsensitiveMatchFunction(pattern string, name string) (bool, error) {
+	foldCaser := cases.Fold()
 	return filepath.Match(foldCaser.String(pattern), foldCaser.String(name))
 }
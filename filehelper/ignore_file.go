@@ -0,0 +1,233 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.2.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Read ignore files from a file system.
+//    2026-10-19: V1.2.0: Cache ignore files in a cache that can be used concurrently.
+//
+
+package filehelper
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ******** Public constants ********
+
+// IgnoreFileName is the name of the files that contain patterns of files and directories that are not signed.
+const IgnoreFileName = `.filesignerignore`
+
+// ******** Private types ********
+
+// ignoreFile contains the patterns of an ignore file.
+type ignoreFile struct {
+	filePatterns []string
+	dirPatterns  []string
+}
+
+// ignoreFileCache contains the ignore files of a file system that have been read with their directories
+// relative to the root of the file system as keys. A directory without an ignore file has a nil value.
+// The cache may be used by more than one scan at the same time.
+type ignoreFileCache struct {
+	mutex sync.Mutex
+	files map[string]*ignoreFile
+}
+
+// ******** Private variables ********
+
+// modIgnoreFiles contains the ignore files of the current directory that have been read.
+var modIgnoreFiles = newIgnoreFileCache()
+
+// ******** Public functions ********
+
+// IgnoreFilePaths returns the paths of the ignore files that have been applied, relative to the current directory.
+func IgnoreFilePaths() []string {
+	modIgnoreFiles.mutex.Lock()
+	defer modIgnoreFiles.mutex.Unlock()
+
+	result := make([]string, 0, len(modIgnoreFiles.files))
+	for dirPath, f := range modIgnoreFiles.files {
+		if f != nil {
+			result = append(result, filepath.Join(filepath.FromSlash(dirPath), IgnoreFileName))
+		}
+	}
+
+	return result
+}
+
+// ******** Private functions ********
+
+// newIgnoreFileCache creates an empty cache of ignore files.
+func newIgnoreFileCache() *ignoreFileCache {
+	return &ignoreFileCache{files: make(map[string]*ignoreFile)}
+}
+
+// isPathIgnored returns "true", if a path relative to the current directory or any of its directories
+// is ignored by the ignore files in the current directory and the directories of the path.
+func isPathIgnored(relPath string, isDir bool) (bool, error) {
+	elements := strings.Split(filepath.ToSlash(filepath.Clean(relPath)), `/`)
+
+	for i := range elements {
+		isIgnored, err := isEntryIgnored(nil, modIgnoreFiles, path.Join(elements[:i+1]...), isDir || i < len(elements)-1)
+		if isIgnored || err != nil {
+			return isIgnored, err
+		}
+	}
+
+	return false, nil
+}
+
+// isEntryIgnored returns "true", if an entry with a path relative to the root of a file system is ignored
+// by the ignore files in the directories above it. The directories themselves are not checked.
+// The ignore files that have been read are cached in ignoreFiles.
+// A nil file system is the current directory.
+func isEntryIgnored(fsys fs.FS, ignoreFiles *ignoreFileCache, relPath string, isDir bool) (bool, error) {
+	slashPath := path.Clean(filepath.ToSlash(relPath))
+	name := path.Base(slashPath)
+
+	dirPath := `.`
+	rest := slashPath
+	for {
+		f, err := ignoreFiles.load(fsys, dirPath)
+		if err != nil {
+			return false, err
+		}
+
+		if f != nil {
+			patterns := f.filePatterns
+			if isDir {
+				patterns = f.dirPatterns
+			}
+
+			var isMatch bool
+			isMatch, err = MatchesAnyEntry(patterns, name, rest, false)
+			if isMatch || err != nil {
+				return isMatch, err
+			}
+		}
+
+		first, remainder, found := strings.Cut(rest, `/`)
+		if !found {
+			return false, nil
+		}
+
+		dirPath = path.Join(dirPath, first)
+		rest = remainder
+	}
+}
+
+// load reads the ignore file in a directory relative to the root of a file system, if it is not in the cache, yet.
+// It returns nil, if there is no ignore file in the directory.
+func (c *ignoreFileCache) load(fsys fs.FS, dirPath string) (*ignoreFile, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	result, isLoaded := c.files[dirPath]
+	if isLoaded {
+		return result, nil
+	}
+
+	filePath := filepath.Join(filepath.FromSlash(dirPath), IgnoreFileName)
+
+	var content []byte
+	var err error
+	if fsys == nil {
+		content, err = os.ReadFile(filePath)
+	} else {
+		content, err = fs.ReadFile(fsys, path.Join(dirPath, IgnoreFileName))
+	}
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	} else {
+		result, err = parseIgnoreFile(filePath, content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.files[dirPath] = result
+
+	return result, nil
+}
+
+// parseIgnoreFile parses the content of an ignore file.
+// Each line contains one pattern. Patterns that end with a path separator match directories,
+// all others match files. Empty lines and lines that start with "#" are ignored.
+func parseIgnoreFile(filePath string, content []byte) (*ignoreFile, error) {
+	result := &ignoreFile{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		pattern := strings.TrimSpace(scanner.Text())
+		if len(pattern) == 0 || pattern[0] == '#' {
+			continue
+		}
+
+		isDirPattern := strings.HasSuffix(pattern, `/`) || strings.HasSuffix(pattern, string(filepath.Separator))
+		pattern = strings.TrimRight(pattern, `/`+string(filepath.Separator))
+
+		err := checkIgnorePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf(`Invalid pattern in line %d of '%s': %w`, lineNumber, filePath, err)
+		}
+
+		if isDirPattern {
+			result.dirPatterns = append(result.dirPatterns, pattern)
+		} else {
+			result.filePatterns = append(result.filePatterns, pattern)
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+// checkIgnorePattern checks that a pattern of an ignore file is valid.
+func checkIgnorePattern(pattern string) error {
+	if len(pattern) == 0 {
+		return errors.New(`Pattern is empty`)
+	}
+
+	if IsPathPattern(pattern) {
+		return CheckPathPattern(pattern)
+	}
+
+	_, err := filepath.Match(pattern, ``)
+
+	return err
+}
@@ -0,0 +1,161 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Concurrent scans.
+//
+
+package filehelper
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+// ******** Test functions ********
+
+func TestIgnoreFiles(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dir, `docs`, `generated`), 0755)
+	_ = os.WriteFile(filepath.Join(dir, IgnoreFileName), []byte("# Comment\n*.tmp\nbuild/\n"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, `docs`, IgnoreFileName), []byte("generated/\nsrc/**/*.bak\n"), 0644)
+
+	// The ignore files are cached with paths relative to the current directory.
+	t.Chdir(dir)
+	modIgnoreFiles = newIgnoreFileCache()
+	t.Cleanup(func() { modIgnoreFiles = newIgnoreFileCache() })
+
+	checkPathIgnored(t, `a.tmp`, true)
+	checkPathIgnored(t, `a.go`, false)
+	checkPathIgnored(t, `sub/build/x.o`, true)
+	checkPathIgnored(t, `docs/generated/y.html`, true)
+	checkPathIgnored(t, `generated/y.html`, false)
+	checkPathIgnored(t, `docs/src/a/b.bak`, true)
+	checkPathIgnored(t, `src/a/b.bak`, false)
+
+	if len(IgnoreFilePaths()) != 2 {
+		t.Fatalf(`Wrong ignore file paths: %v`, IgnoreFilePaths())
+	}
+}
+
+func TestScanFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		IgnoreFileName:           {Data: []byte("*.tmp\n")},
+		`a.go`:                   {},
+		`a.tmp`:                  {},
+		`docs/` + IgnoreFileName: {Data: []byte("generated/\n")},
+		`docs/b.md`:              {},
+		`docs/generated/c.md`:    {},
+	}
+
+	result, err := ScanFS(fsys, nil, nil, nil, nil, true, false)
+	if err != nil {
+		t.Fatalf(`Error scanning file system: %v`, err)
+	}
+
+	for _, filePath := range []string{IgnoreFileName, `a.go`, `docs/` + IgnoreFileName, `docs/b.md`} {
+		if !result.Contains(filePath) {
+			t.Errorf(`File '%s' not found`, filePath)
+		}
+	}
+
+	if result.Size() != 4 {
+		t.Fatalf(`Wrong files found: %v`, result.Elements())
+	}
+}
+
+func TestScanFSConcurrently(t *testing.T) {
+	goFS := fstest.MapFS{
+		IgnoreFileName:  {Data: []byte("*.tmp\n")},
+		`a.go`:          {},
+		`a.tmp`:         {},
+		`sub/b.go`:      {},
+		`sub/b.md`:      {},
+		`other/c.go`:    {},
+		`other/c.tmp`:   {},
+		`other/d/e.txt`: {},
+	}
+	mdFS := fstest.MapFS{
+		`x.md`:       {},
+		`x.tmp`:      {},
+		`sub/y.md`:   {},
+		`sub/y.go`:   {},
+		`deep/z.md`:  {},
+		`deep/z.txt`: {},
+	}
+
+	// Each scan has different options and a different file system, so mixed up state shows in the results.
+	goExpected := []string{IgnoreFileName, `a.go`, `other/c.go`, `sub/b.go`}
+	mdExpected := []string{`x.md`}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 200)
+	for range 50 {
+		wg.Go(func() {
+			result, err := ScanFS(goFS, nil, []string{`*.md`, `*.txt`}, nil, nil, true, false)
+			if err != nil || !slices.Equal(slices.Sorted(slices.Values(result.Elements())), goExpected) {
+				errs <- fmt.Sprintf(`Wrong files found: %v, %v`, result, err)
+			}
+		})
+		wg.Go(func() {
+			result, err := ScanFS(mdFS, []string{`*.md`}, nil, nil, nil, false, false)
+			if err != nil || !slices.Equal(slices.Sorted(slices.Values(result.Elements())), mdExpected) {
+				errs <- fmt.Sprintf(`Wrong files found: %v, %v`, result, err)
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for e := range errs {
+		t.Fatal(e)
+	}
+}
+
+func TestInvalidIgnoreFile(t *testing.T) {
+	_, err := parseIgnoreFile(IgnoreFileName, []byte("*.go\n/abs/path\n"))
+	if err == nil {
+		t.Fatal(`Invalid pattern not detected`)
+	}
+}
+
+// ******** Private functions ********
+
+// checkPathIgnored checks that a path is ignored or not.
+func checkPathIgnored(t *testing.T, relPath string, expected bool) {
+	isIgnored, err := isPathIgnored(relPath, false)
+	if err != nil {
+		t.Fatalf(`Error checking '%s': %v`, relPath, err)
+	}
+
+	if isIgnored != expected {
+		t.Errorf(`Path '%s' is ignored: %t`, relPath, isIgnored)
+	}
+}
@@ -0,0 +1,150 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.1
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.0.1: Matcher is initialized with the package.
+//
+
+package filehelper
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ******** Private constants ********
+
+// globStar is the path pattern element that matches any number of directories.
+const globStar = `**`
+
+// ******** Public functions ********
+
+// IsPathPattern returns "true", if the pattern contains a path separator and is matched against
+// paths relative to the scan root instead of names.
+func IsPathPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, `/`+string(filepath.Separator))
+}
+
+// CheckPathPattern checks that a path pattern is relative to the scan root and has a valid syntax.
+func CheckPathPattern(pattern string) error {
+	if filepath.IsAbs(pattern) || len(filepath.VolumeName(pattern)) != 0 || strings.HasPrefix(filepath.ToSlash(pattern), `/`) {
+		return fmt.Errorf(`Path pattern '%s' must be relative`, pattern)
+	}
+
+	for _, element := range splitPathPattern(pattern) {
+		if len(element) == 0 || element == `.` || element == `..` {
+			return fmt.Errorf(`Path pattern '%s' contains an invalid element '%s'`, pattern, element)
+		}
+
+		_, err := filepath.Match(element, ``)
+		if err != nil {
+			return fmt.Errorf(`Path pattern '%s' is invalid: %w`, pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// MatchesAnyEntry returns true if any pattern matches a directory entry.
+// Name patterns are matched against the name of the entry and path patterns against its path.
+// If partial is set, a path pattern also matches a directory that may contain a matching path.
+func MatchesAnyEntry(patterns []string, name string, path string, partial bool) (bool, error) {
+	var pathElements []string
+	for _, pattern := range patterns {
+		var isMatch bool
+		var err error
+		if IsPathPattern(pattern) {
+			if pathElements == nil {
+				pathElements = strings.Split(filepath.ToSlash(filepath.Clean(path)), `/`)
+			}
+
+			isMatch, err = matchPathElements(splitPathPattern(pattern), pathElements, partial)
+		} else {
+			isMatch, err = matcherMatchFunc(pattern, name)
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if isMatch {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ******** Private functions ********
+
+// splitPathPattern splits a path pattern into its elements.
+// A leading "./" and trailing separators are ignored.
+func splitPathPattern(pattern string) []string {
+	slashPattern := strings.TrimPrefix(filepath.ToSlash(pattern), `./`)
+	slashPattern = strings.TrimRight(slashPattern, `/`)
+
+	return strings.Split(slashPattern, `/`)
+}
+
+// matchPathElements matches path elements against pattern elements.
+// A "**" pattern element matches any number of path elements, including none.
+// If partial is set, a path that is shorter than the pattern matches, if it matches the beginning of the pattern.
+func matchPathElements(patternElements []string, pathElements []string, partial bool) (bool, error) {
+	if len(patternElements) == 0 {
+		return len(pathElements) == 0, nil
+	}
+
+	if len(pathElements) == 0 {
+		if partial {
+			return true, nil
+		}
+
+		// Only "**" elements may be left.
+		for _, element := range patternElements {
+			if element != globStar {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}
+
+	if patternElements[0] == globStar {
+		isMatch, err := matchPathElements(patternElements[1:], pathElements, partial)
+		if isMatch || err != nil {
+			return isMatch, err
+		}
+
+		return matchPathElements(patternElements, pathElements[1:], partial)
+	}
+
+	isMatch, err := matcherMatchFunc(patternElements[0], pathElements[0])
+	if !isMatch || err != nil {
+		return false, err
+	}
+
+	return matchPathElements(patternElements[1:], pathElements[1:], partial)
+}
@@ -0,0 +1,106 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package filehelper
+
+import (
+	"runtime"
+	"testing"
+)
+
+// ******** Test functions ********
+
+func TestPathPatterns(t *testing.T) {
+	patterns := []string{`docs/generated/**`}
+
+	checkEntryMatch(t, patterns, `docs/generated`, false, true)
+	checkEntryMatch(t, patterns, `docs/generated/a/b.html`, false, true)
+	checkEntryMatch(t, patterns, `src/generated/b.go`, false, false)
+	checkEntryMatch(t, patterns, `generated`, false, false)
+
+	patterns = []string{`src/**/*.go`}
+
+	checkEntryMatch(t, patterns, `src/a.go`, false, true)
+	checkEntryMatch(t, patterns, `src/x/y/a.go`, false, true)
+	checkEntryMatch(t, patterns, `src/x/y/a.txt`, false, false)
+	checkEntryMatch(t, patterns, `other/a.go`, false, false)
+}
+
+func TestPartialPathPatterns(t *testing.T) {
+	patterns := []string{`src/*/test`}
+
+	checkEntryMatch(t, patterns, `src`, true, true)
+	checkEntryMatch(t, patterns, `src/pkg`, true, true)
+	checkEntryMatch(t, patterns, `src/pkg/test`, true, true)
+	checkEntryMatch(t, patterns, `src/pkg/other`, true, false)
+	checkEntryMatch(t, patterns, `docs`, true, false)
+}
+
+func TestNamePatternsInEntryMatch(t *testing.T) {
+	isMatch, err := MatchesAnyEntry([]string{`*.go`}, `a.go`, `src/a.go`, false)
+	if err != nil || !isMatch {
+		t.Fatalf(`Name pattern does not match: %v`, err)
+	}
+}
+
+func TestPathPatternCase(t *testing.T) {
+	isMatch, _ := MatchesAnyEntry([]string{`Docs/**`}, `a.txt`, `docs/a.txt`, false)
+	if isMatch != (runtime.GOOS == `windows`) {
+		t.Fatalf(`Case of path pattern is not handled correctly`)
+	}
+}
+
+func TestCheckPathPattern(t *testing.T) {
+	for _, pattern := range []string{`docs/**`, `./src/*.go`, `a/[bc]/d/`} {
+		err := CheckPathPattern(pattern)
+		if err != nil {
+			t.Fatalf(`Valid pattern '%s' is rejected: %v`, pattern, err)
+		}
+	}
+
+	for _, pattern := range []string{`/docs/**`, `../src/*.go`, `a//b`, `a/[b/c`} {
+		err := CheckPathPattern(pattern)
+		if err == nil {
+			t.Fatalf(`Invalid pattern '%s' is not detected`, pattern)
+		}
+	}
+}
+
+// ******** Private functions ********
+
+// checkEntryMatch checks that a path matches the patterns or not.
+func checkEntryMatch(t *testing.T, patterns []string, path string, partial bool, expected bool) {
+	isMatch, err := MatchesAnyEntry(patterns, `name`, path, partial)
+	if err != nil {
+		t.Fatalf(`Error matching '%s': %v`, path, err)
+	}
+
+	if isMatch != expected {
+		t.Errorf(`Path '%s' matches %v (partial: %t): %t`, path, patterns, partial, isMatch)
+	}
+}
@@ -0,0 +1,184 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+// Package filemetadata implements the metadata of files that are signed together with their content.
+//
+// The metadata are the size and the permission bits of a file. The permission bits are
+// only available on platforms with POSIX file modes. On other platforms, e.g. Windows,
+// only the size is recorded and recorded permission bits are not compared.
+package filemetadata
+
+import (
+	"crypto/sha3"
+	"filesigner/numberhelper"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// ******** Public types ********
+
+// Metadata contains the metadata of a file.
+// Mode contains the permission bits as 4 octal digits, including the setuid, setgid and sticky bits.
+// It is empty, if the permission bits are not recorded.
+type Metadata struct {
+	Size int64  `json:"size"`
+	Mode string `json:"mode,omitempty"`
+}
+
+// ******** Private constants ********
+
+// These are the POSIX values of the special mode bits.
+const (
+	posixSetuid = 0o4000
+	posixSetgid = 0o2000
+	posixSticky = 0o1000
+
+	posixExecutable = 0o0111
+)
+
+// modeLength is the number of octal digits of a mode.
+const modeLength = 4
+
+// ******** Type creation ********
+
+// FromFileInfo returns the metadata of a file.
+// The mode is only set on platforms with POSIX file modes.
+func FromFileInfo(fi fs.FileInfo) *Metadata {
+	result := &Metadata{Size: fi.Size()}
+
+	if HasPosixModes {
+		result.Mode = formatMode(fi.Mode())
+	}
+
+	return result
+}
+
+// ******** Public functions ********
+
+// Check checks that the metadata have valid values.
+func (m *Metadata) Check() error {
+	if m.Size < 0 {
+		return fmt.Errorf(`Invalid size: %d`, m.Size)
+	}
+
+	if len(m.Mode) != 0 {
+		_, err := strconv.ParseUint(m.Mode, 8, 16)
+		if err != nil || len(m.Mode) != modeLength {
+			return fmt.Errorf(`Invalid mode: '%s'`, m.Mode)
+		}
+	}
+
+	return nil
+}
+
+// Bytes returns the canonical byte representation of the metadata that is hashed.
+func (m *Metadata) Bytes() []byte {
+	return []byte(strconv.FormatInt(m.Size, 10) + `:` + m.Mode)
+}
+
+// Differences returns descriptions of the differences between the recorded and the actual metadata.
+// The modes are only compared if both are present.
+func Differences(recorded *Metadata, actual *Metadata) []string {
+	var result []string
+
+	if recorded.Size != actual.Size {
+		result = append(result, fmt.Sprintf(`size is %d instead of %d`, actual.Size, recorded.Size))
+	}
+
+	if len(recorded.Mode) != 0 && len(actual.Mode) != 0 && recorded.Mode != actual.Mode {
+		result = append(result, fmt.Sprintf(`mode is %s instead of %s%s`, actual.Mode, recorded.Mode, modeHint(recorded.Mode, actual.Mode)))
+	}
+
+	return result
+}
+
+// SignedHashValue returns the hash value that is signed for a file with the given content hash value and metadata.
+// If there are no metadata, the content hash value is signed.
+func SignedHashValue(hashValue []byte, m *Metadata) []byte {
+	if m == nil {
+		return hashValue
+	}
+
+	metadataBytes := m.Bytes()
+
+	hasher := sha3.New512()
+	_, _ = hasher.Write(hashValue)
+	_, _ = hasher.Write(metadataBytes)
+	_, _ = hasher.Write(numberhelper.IntAsShortestBigEndianBytes(len(metadataBytes)))
+
+	return hasher.Sum(nil)
+}
+
+// ******** Private functions ********
+
+// formatMode converts a file mode into its POSIX representation with 4 octal digits.
+func formatMode(mode fs.FileMode) string {
+	result := uint32(mode.Perm())
+
+	if mode&fs.ModeSetuid != 0 {
+		result |= posixSetuid
+	}
+
+	if mode&fs.ModeSetgid != 0 {
+		result |= posixSetgid
+	}
+
+	if mode&fs.ModeSticky != 0 {
+		result |= posixSticky
+	}
+
+	return fmt.Sprintf(`%04o`, result)
+}
+
+// modeHint returns a hint for the most important mode changes.
+func modeHint(recordedMode string, actualMode string) string {
+	// Both modes have been checked, so they can be parsed.
+	recorded, _ := strconv.ParseUint(recordedMode, 8, 16)
+	actual, _ := strconv.ParseUint(actualMode, 8, 16)
+
+	var hints []string
+	if recorded&posixExecutable != 0 && actual&posixExecutable == 0 {
+		hints = append(hints, `executable bit lost`)
+	}
+
+	if recorded&posixExecutable == 0 && actual&posixExecutable != 0 {
+		hints = append(hints, `executable bit added`)
+	}
+
+	if actual&^recorded&(posixSetuid|posixSetgid) != 0 {
+		hints = append(hints, `setuid or setgid bit added`)
+	}
+
+	if len(hints) == 0 {
+		return ``
+	}
+
+	return ` (` + strings.Join(hints, `, `) + `)`
+}
@@ -0,0 +1,95 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package filemetadata
+
+import (
+	"bytes"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+// ******** Test functions ********
+
+func TestFormatMode(t *testing.T) {
+	mode := formatMode(fs.ModeSetuid | fs.ModeSticky | 0o750)
+	if mode != `5750` {
+		t.Fatalf(`Mode is '%s' instead of '5750'`, mode)
+	}
+
+	m := &Metadata{Size: 1, Mode: mode}
+	if m.Check() != nil {
+		t.Fatalf(`Valid mode '%s' is rejected`, mode)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, m := range []*Metadata{{Size: -1}, {Mode: `755`}, {Mode: `0789`}, {Mode: `rwxr`}} {
+		if m.Check() == nil {
+			t.Fatalf(`Invalid metadata not detected: %v`, m)
+		}
+	}
+}
+
+func TestDifferences(t *testing.T) {
+	recorded := &Metadata{Size: 10, Mode: `0755`}
+
+	differences := Differences(recorded, &Metadata{Size: 10, Mode: `0755`})
+	if len(differences) != 0 {
+		t.Fatalf(`Differences found for equal metadata: %v`, differences)
+	}
+
+	differences = Differences(recorded, &Metadata{Size: 12, Mode: `4644`})
+	if len(differences) != 2 {
+		t.Fatalf(`Wrong number of differences: %v`, differences)
+	}
+
+	if !strings.Contains(differences[1], `executable bit lost`) || !strings.Contains(differences[1], `setuid or setgid bit added`) {
+		t.Fatalf(`Mode hints are missing: %s`, differences[1])
+	}
+
+	differences = Differences(recorded, &Metadata{Size: 10})
+	if len(differences) != 0 {
+		t.Fatalf(`Missing mode is compared: %v`, differences)
+	}
+}
+
+func TestSignedHashValue(t *testing.T) {
+	hashValue := []byte(`Hash value`)
+
+	if !bytes.Equal(SignedHashValue(hashValue, nil), hashValue) {
+		t.Fatal(`Hash value without metadata has been changed`)
+	}
+
+	withMode := SignedHashValue(hashValue, &Metadata{Size: 10, Mode: `0644`})
+	otherMode := SignedHashValue(hashValue, &Metadata{Size: 10, Mode: `0755`})
+	if bytes.Equal(withMode, hashValue) || bytes.Equal(withMode, otherMode) {
+		t.Fatal(`Metadata do not change the signed hash value`)
+	}
+}
@@ -20,10 +20,11 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.1.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Sign metadata of files.
 //
 
 package filesignature
@@ -31,6 +32,7 @@ package filesignature
 import (
 	"filesigner/base32encoding"
 	"filesigner/filehasher"
+	"filesigner/filemetadata"
 	"filesigner/hashsignature"
 	"filesigner/maphelper"
 	"fmt"
@@ -40,6 +42,7 @@ import (
 // ******** Public functions ********
 
 // SignFileHashes creates signatures for file hashes.
+// If a hash result contains metadata, they are signed together with the hash value.
 func SignFileHashes(hashSigner hashsignature.HashSigner,
 	hashResultList map[string]*filehasher.HashResult) (map[string]string, []string, error) {
 	filePathList := maphelper.SortedKeys(hashResultList)
@@ -57,7 +60,8 @@ func makeHashSignatures(hashSigner hashsignature.HashSigner,
 
 	var signature []byte
 	for _, filePath := range filePathList {
-		signature, err = hashSigner.SignHash(hashResultList[filePath].HashValue)
+		hashResult := hashResultList[filePath]
+		signature, err = hashSigner.SignHash(filemetadata.SignedHashValue(hashResult.HashValue, hashResult.Metadata))
 		if err != nil {
 			return nil, nil, fmt.Errorf(`Could not sign hash of file '%s': %w`, filePath, err)
 		}
@@ -20,10 +20,12 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 2.0.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Verify metadata of files.
+//    2026-10-19: V2.0.0: Return the errors by file path.
 //
 
 package filesignature
@@ -31,6 +33,7 @@ package filesignature
 import (
 	"filesigner/base32encoding"
 	"filesigner/filehasher"
+	"filesigner/filemetadata"
 	"filesigner/hashsignature"
 	"filesigner/maphelper"
 	"fmt"
@@ -39,14 +42,17 @@ import (
 
 // ******** Public functions ********
 
-// VerifyFileHashes verifies file hashes
+// VerifyFileHashes verifies file hashes.
+// If there are recorded metadata for a file, they have been signed together with the hash value.
+// It returns the paths of the files that have been verified successfully and the errors with the file paths as keys.
 func VerifyFileHashes(hashVerifier hashsignature.HashVerifier,
 	fileSignatures map[string]string,
-	fileHashList map[string]*filehasher.HashResult) ([]string, []error) {
+	fileMetadata map[string]*filemetadata.Metadata,
+	fileHashList map[string]*filehasher.HashResult) ([]string, map[string]error) {
 	var err error
 
 	successCollection := make([]string, 0, len(fileHashList))
-	errCollection := make([]error, 0, len(fileHashList))
+	errCollection := make(map[string]error)
 
 	filePathList := maphelper.SortedKeys(fileSignatures)
 
@@ -59,12 +65,12 @@ func VerifyFileHashes(hashVerifier hashsignature.HashVerifier,
 			signatureString = fileSignatures[filePath]
 			signatureValue, err = base32encoding.DecodeFromString(signatureString)
 			if err != nil {
-				errCollection = append(errCollection, fmt.Errorf(`Signature of file '%s' has invalid encoding: %w`, normalizedFilePath, err))
+				errCollection[normalizedFilePath] = fmt.Errorf(`Signature of file '%s' has invalid encoding: %w`, normalizedFilePath, err)
 			} else {
-				if hashVerifier.VerifyHash(fileHashResult.HashValue, signatureValue) {
+				if hashVerifier.VerifyHash(filemetadata.SignedHashValue(fileHashResult.HashValue, fileMetadata[filePath]), signatureValue) {
 					successCollection = append(successCollection, normalizedFilePath)
 				} else {
-					errCollection = append(errCollection, fmt.Errorf(`File '%s' has been modified`, normalizedFilePath))
+					errCollection[normalizedFilePath] = fmt.Errorf(`File '%s' has been modified`, normalizedFilePath)
 				}
 			}
 		}
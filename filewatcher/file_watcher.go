@@ -0,0 +1,80 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Report changed directories.
+//
+
+// Package filewatcher reports changes of files as soon as they happen.
+//
+// The directories that contain the files are watched, so that files that are replaced
+// by renaming another file over them are still reported.
+// Their parent directories are watched, as well, so that moving or deleting any of them is reported as an error.
+// Watching is only supported on Linux, where it uses inotify.
+package filewatcher
+
+import "errors"
+
+// ******** Public types ********
+
+// Watcher reports the changes of a set of files.
+type Watcher struct {
+	// Events receives the paths of the files that have been changed, created or deleted.
+	// The paths are the ones that have been passed to New.
+	Events <-chan string
+
+	// Errors receives the errors that occurred while watching.
+	// After an error changes may have been lost, so all files should be checked.
+	Errors <-chan error
+
+	platformWatcher
+}
+
+// ******** Public variables ********
+
+// ErrNotSupported is returned if watching files is not supported on this platform.
+var ErrNotSupported = errors.New(`Watching files is not supported on this platform`)
+
+// ErrDirChanged is reported if a directory of the watched files has been moved, deleted or created.
+// Then the directory is not watched any more, so a new watcher is needed.
+var ErrDirChanged = errors.New(`Directory of watched files has been changed`)
+
+// ErrEventsLost is reported if changes have been lost, because they occurred faster than they could be read.
+var ErrEventsLost = errors.New(`Changes of watched files have been lost`)
+
+// ******** Type creation ********
+
+// New creates a watcher for the files with the given paths.
+// Directories that do not exist are not watched.
+func New(filePaths []string) (*Watcher, error) {
+	return newWatcher(filePaths)
+}
+
+// ******** Public functions ********
+
+// Close stops watching. The channels are closed afterward.
+func (w *Watcher) Close() error {
+	return w.close()
+}
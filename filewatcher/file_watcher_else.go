@@ -0,0 +1,49 @@
+//go:build !linux
+
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package filewatcher
+
+// ******** Private types ********
+
+// platformWatcher contains the platform-specific data of a watcher.
+// There are none, as watching is not supported on this platform.
+type platformWatcher struct{}
+
+// ******** Private functions ********
+
+// newWatcher returns ErrNotSupported, as watching is not supported on this platform.
+func newWatcher([]string) (*Watcher, error) {
+	return nil, ErrNotSupported
+}
+
+// close does nothing, as there is nothing to close.
+func (pw *platformWatcher) close() error {
+	return nil
+}
@@ -0,0 +1,255 @@
+//go:build linux
+
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Watch parent directories and report moved or deleted directories.
+//
+
+package filewatcher
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"golang.org/x/sys/unix"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ******** Private types ********
+
+// platformWatcher contains the inotify data of a watcher.
+type platformWatcher struct {
+	file       *os.File
+	dirs       map[int]string
+	neededDirs map[string]bool
+	files      map[string]string
+	events     chan string
+	errors     chan error
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+// ******** Private constants ********
+
+// watchMask contains the inotify events that are watched in the directories.
+const watchMask = unix.IN_MODIFY |
+	unix.IN_CLOSE_WRITE |
+	unix.IN_ATTRIB |
+	unix.IN_CREATE |
+	unix.IN_DELETE |
+	unix.IN_MOVED_FROM |
+	unix.IN_MOVED_TO |
+	unix.IN_DELETE_SELF |
+	unix.IN_MOVE_SELF
+
+// lostWatchMask contains the inotify events that mean that a directory is no longer watched.
+const lostWatchMask = unix.IN_DELETE_SELF |
+	unix.IN_MOVE_SELF |
+	unix.IN_IGNORED
+
+// eventBufferSize is the size of the buffer for inotify events.
+const eventBufferSize = 64 * (unix.SizeofInotifyEvent + unix.NAME_MAX + 1)
+
+// ******** Private functions ********
+
+// newWatcher creates a watcher that watches the directories of the files and their parent directories with inotify.
+// The parent directories are watched, so that moving or deleting any of them is noticed.
+func newWatcher(filePaths []string) (*Watcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf(`Could not initialize inotify: %w`, err)
+	}
+
+	// A non-blocking file uses the runtime poller, so closing it ends a pending read.
+	result := &Watcher{platformWatcher: platformWatcher{
+		file:       os.NewFile(uintptr(fd), `inotify`),
+		dirs:       make(map[int]string),
+		neededDirs: make(map[string]bool),
+		files:      make(map[string]string, len(filePaths)),
+		events:     make(chan string),
+		errors:     make(chan error),
+		done:       make(chan struct{}),
+	}}
+	result.Events = result.events
+	result.Errors = result.errors
+
+	pw := &result.platformWatcher
+
+	for _, filePath := range filePaths {
+		cleanPath := filepath.Clean(filePath)
+		pw.files[cleanPath] = filePath
+
+		pw.addNeededDirs(filepath.Dir(cleanPath))
+	}
+
+	for dirPath := range pw.neededDirs {
+		var wd int
+		wd, err = unix.InotifyAddWatch(fd, dirPath, watchMask)
+		if err != nil {
+			if errors.Is(err, unix.ENOENT) {
+				continue
+			}
+
+			_ = pw.file.Close()
+			return nil, fmt.Errorf(`Could not watch directory '%s': %w`, dirPath, err)
+		}
+
+		pw.dirs[wd] = dirPath
+	}
+
+	go pw.readEvents()
+
+	return result, nil
+}
+
+// addNeededDirs adds a directory and its parent directories up to the current or the root directory to the needed directories.
+func (pw *platformWatcher) addNeededDirs(dirPath string) {
+	for !pw.neededDirs[dirPath] {
+		pw.neededDirs[dirPath] = true
+
+		parentPath := filepath.Dir(dirPath)
+		if parentPath == dirPath {
+			return
+		}
+
+		dirPath = parentPath
+	}
+}
+
+// close stops the reading of events and closes the inotify file.
+func (pw *platformWatcher) close() error {
+	var err error
+	pw.closeOnce.Do(func() {
+		close(pw.done)
+		err = pw.file.Close()
+	})
+
+	return err
+}
+
+// readEvents reads the inotify events and sends the paths of the changed files to the events channel
+// until the watcher is closed.
+func (pw *platformWatcher) readEvents() {
+	defer close(pw.errors)
+	defer close(pw.events)
+
+	buffer := make([]byte, eventBufferSize)
+	for {
+		n, err := pw.file.Read(buffer)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				pw.sendError(fmt.Errorf(`Could not read inotify events: %w`, err))
+			}
+
+			return
+		}
+
+		if !pw.processEvents(buffer[:n]) {
+			return
+		}
+	}
+}
+
+// processEvents sends the paths of the files in the events in a buffer to the events channel.
+// It returns false, if the watcher has been closed.
+func (pw *platformWatcher) processEvents(buffer []byte) bool {
+	for offset := 0; offset+unix.SizeofInotifyEvent <= len(buffer); {
+		wd := int(int32(binary.NativeEndian.Uint32(buffer[offset:])))
+		mask := binary.NativeEndian.Uint32(buffer[offset+4:])
+		nameLen := int(binary.NativeEndian.Uint32(buffer[offset+12:]))
+
+		nameStart := offset + unix.SizeofInotifyEvent
+		name := strings.TrimRight(string(buffer[nameStart:nameStart+nameLen]), "\x00")
+		offset = nameStart + nameLen
+
+		dirPath, isKnown := pw.dirs[wd]
+		if mask&unix.IN_IGNORED != 0 {
+			delete(pw.dirs, wd)
+		}
+
+		isOpen := true
+		switch {
+		case mask&unix.IN_Q_OVERFLOW != 0:
+			isOpen = pw.sendError(ErrEventsLost)
+
+		case !isKnown:
+			// Events of a watch that has already been removed are not relevant.
+
+		case mask&lostWatchMask != 0:
+			// The directory itself has been deleted or moved, so it is no longer watched.
+			isOpen = pw.sendError(fmt.Errorf(`%w: '%s'`, ErrDirChanged, dirPath))
+
+		case mask&unix.IN_ISDIR != 0:
+			// A needed directory that has been created, deleted or moved has to be watched anew.
+			changedPath := filepath.Join(dirPath, name)
+			if pw.neededDirs[changedPath] {
+				isOpen = pw.sendError(fmt.Errorf(`%w: '%s'`, ErrDirChanged, changedPath))
+			}
+
+		default:
+			isOpen = pw.sendFile(filepath.Join(dirPath, name))
+		}
+
+		if !isOpen {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sendFile sends the path of a file to the events channel, if it is watched.
+// It returns false, if the watcher has been closed.
+func (pw *platformWatcher) sendFile(cleanPath string) bool {
+	filePath, isWatched := pw.files[cleanPath]
+	if !isWatched {
+		return true
+	}
+
+	select {
+	case pw.events <- filePath:
+		return true
+
+	case <-pw.done:
+		return false
+	}
+}
+
+// sendError sends an error to the errors channel.
+// It returns false, if the watcher has been closed.
+func (pw *platformWatcher) sendError(err error) bool {
+	select {
+	case pw.errors <- err:
+		return true
+
+	case <-pw.done:
+		return false
+	}
+}
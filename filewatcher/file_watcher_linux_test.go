@@ -0,0 +1,203 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package filewatcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ******** Private constants ********
+
+// eventTimeout is the time after which a missing event is an error.
+const eventTimeout = 5 * time.Second
+
+// ******** Test functions ********
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	watchedPath := filepath.Join(dir, `watched.txt`)
+	otherPath := filepath.Join(dir, `other.txt`)
+	writeTestFile(t, watchedPath, `Original content`)
+
+	w, err := New([]string{watchedPath, filepath.Join(dir, `missing`, `file.txt`)})
+	if err != nil {
+		t.Fatalf(`Could not create watcher: %v`, err)
+	}
+	defer func() { _ = w.Close() }()
+
+	// Changes of files that are not watched are not reported.
+	writeTestFile(t, otherPath, `Other content`)
+	writeTestFile(t, watchedPath, `Modified content`)
+	checkEvent(t, w, watchedPath)
+
+	// A file that is replaced by renaming another file over it is reported.
+	err = os.Rename(otherPath, watchedPath)
+	if err != nil {
+		t.Fatalf(`Could not rename file: %v`, err)
+	}
+	checkEvent(t, w, watchedPath)
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf(`Could not close watcher: %v`, err)
+	}
+
+	for range w.Events {
+		// Drain the events until the channel is closed.
+	}
+}
+
+func TestMovedDirectory(t *testing.T) {
+	dir := t.TempDir()
+	subPath := filepath.Join(dir, `sub`)
+	watchedPath := filepath.Join(subPath, `a.txt`)
+	makeTestDir(t, subPath)
+	writeTestFile(t, watchedPath, `Original content`)
+
+	w := newTestWatcher(t, watchedPath)
+
+	// Moving the directory of a watched file ends the watch of the directory.
+	renameTestFile(t, subPath, filepath.Join(dir, `sub.old`))
+	checkDirChanged(t, w)
+	_ = w.Close()
+
+	// The watch is created anew for the new directory, after it has been created.
+	w = newTestWatcher(t, watchedPath)
+
+	makeTestDir(t, subPath)
+	checkDirChanged(t, w)
+	_ = w.Close()
+
+	w = newTestWatcher(t, watchedPath)
+
+	writeTestFile(t, watchedPath, `Evil content`)
+	checkEvent(t, w, watchedPath)
+}
+
+func TestMovedParentDirectory(t *testing.T) {
+	dir := t.TempDir()
+	parentPath := filepath.Join(dir, `parent`)
+	subPath := filepath.Join(parentPath, `sub`)
+	watchedPath := filepath.Join(subPath, `a.txt`)
+	makeTestDir(t, subPath)
+	writeTestFile(t, watchedPath, `Original content`)
+
+	w := newTestWatcher(t, watchedPath)
+
+	// Moving a parent directory is reported, although the directory of the file is unchanged.
+	renameTestFile(t, parentPath, filepath.Join(dir, `parent.old`))
+	checkDirChanged(t, w)
+}
+
+// ******** Private functions ********
+
+// newTestWatcher creates a watcher for a file that is closed at the end of the test.
+func newTestWatcher(t *testing.T, filePath string) *Watcher {
+	w, err := New([]string{filePath})
+	if err != nil {
+		t.Fatalf(`Could not create watcher: %v`, err)
+	}
+	t.Cleanup(func() { _ = w.Close() })
+
+	return w
+}
+
+// makeTestDir creates a test directory.
+func makeTestDir(t *testing.T, dirPath string) {
+	err := os.MkdirAll(dirPath, 0755)
+	if err != nil {
+		t.Fatalf(`Could not create directory '%s': %v`, dirPath, err)
+	}
+}
+
+// renameTestFile renames a test file or directory.
+func renameTestFile(t *testing.T, oldPath string, newPath string) {
+	err := os.Rename(oldPath, newPath)
+	if err != nil {
+		t.Fatalf(`Could not rename '%s': %v`, oldPath, err)
+	}
+}
+
+// checkDirChanged checks that a changed directory is reported as an error.
+// Events of the watched files may be reported before the error.
+func checkDirChanged(t *testing.T, w *Watcher) {
+	timeout := time.After(eventTimeout)
+	for {
+		select {
+		case <-w.Events:
+
+		case err := <-w.Errors:
+			if !errors.Is(err, ErrDirChanged) {
+				t.Fatalf(`Error is '%v' instead of a changed directory`, err)
+			}
+
+			return
+
+		case <-timeout:
+			t.Fatal(`Changed directory is not reported`)
+		}
+	}
+}
+
+// writeTestFile writes a test file.
+func writeTestFile(t *testing.T, filePath string, content string) {
+	err := os.WriteFile(filePath, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf(`Could not write file '%s': %v`, filePath, err)
+	}
+}
+
+// checkEvent checks that the next event reports the given file path.
+func checkEvent(t *testing.T, w *Watcher, filePath string) {
+	select {
+	case eventPath := <-w.Events:
+		if eventPath != filePath {
+			t.Fatalf(`Event for '%s' instead of '%s'`, eventPath, filePath)
+		}
+
+	case err := <-w.Errors:
+		t.Fatalf(`Error watching files: %v`, err)
+
+	case <-time.After(eventTimeout):
+		t.Fatalf(`No event for '%s'`, filePath)
+	}
+
+	// A change results in several events, that are all read here.
+	for {
+		select {
+		case <-w.Events:
+		case <-time.After(100 * time.Millisecond):
+			return
+		}
+	}
+}
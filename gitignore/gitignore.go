@@ -0,0 +1,322 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Read ".gitignore" files from a file system.
+//
+
+// Package gitignore implements the matching of paths against the patterns in ".gitignore" files.
+//
+// The patterns follow the rules of git: Blank lines and lines starting with "#" are ignored,
+// "!" negates a pattern, a trailing "/" only matches directories, a pattern with a "/"
+// at the beginning or in the middle is anchored to the directory of the ".gitignore" file
+// and "**" matches any number of directories. Patterns in deeper directories take precedence
+// over patterns in higher directories and later patterns over earlier ones.
+package gitignore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// ******** Public types ********
+
+// Matcher contains the patterns of all ".gitignore" files that have been added.
+type Matcher struct {
+	rules map[string][]*pattern
+}
+
+// ******** Public constants ********
+
+// FileName is the name of the files that contain the patterns.
+const FileName = `.gitignore`
+
+// GitDirName is the name of the git repository directory, which is always ignored.
+const GitDirName = `.git`
+
+// ******** Private types ********
+
+// pattern is one parsed line of a ".gitignore" file.
+type pattern struct {
+	re       *regexp.Regexp
+	negated  bool
+	dirsOnly bool
+}
+
+// ******** Type creation ********
+
+// NewMatcher creates a matcher without patterns.
+func NewMatcher() *Matcher {
+	return &Matcher{rules: make(map[string][]*pattern)}
+}
+
+// ******** Public functions ********
+
+// AddDir reads the ".gitignore" file in the given directory, if there is one.
+// The directory path is relative to the root of the directory tree that is matched.
+func (m *Matcher) AddDir(dirPath string) error {
+	content, err := os.ReadFile(filepath.Join(dirPath, FileName))
+
+	return m.addContent(dirPath, content, err)
+}
+
+// AddFSDir reads the ".gitignore" file in the given directory of a file system, if there is one.
+// The directory path is a slash-separated path relative to the root of the file system.
+func (m *Matcher) AddFSDir(fsys fs.FS, dirPath string) error {
+	content, err := fs.ReadFile(fsys, path.Join(dirPath, FileName))
+
+	return m.addContent(dirPath, content, err)
+}
+
+// Add parses the patterns from a reader as if they were in a ".gitignore" file in the given directory.
+func (m *Matcher) Add(dirPath string, r io.Reader) error {
+	var patterns []*pattern
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		p, err := parsePattern(scanner.Text())
+		if err != nil {
+			return fmt.Errorf(`Invalid pattern in line %d of '%s': %w`, lineNumber, filepath.Join(dirPath, FileName), err)
+		}
+
+		if p != nil {
+			patterns = append(patterns, p)
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return err
+	}
+
+	if len(patterns) != 0 {
+		m.rules[normalizeDir(dirPath)] = patterns
+	}
+
+	return nil
+}
+
+// IsIgnored returns true, if the path is ignored by the patterns of the directories that contain it.
+// The path is relative to the root of the directory tree that is matched.
+func (m *Matcher) IsIgnored(filePath string, isDir bool) bool {
+	slashPath := path.Clean(filepath.ToSlash(filePath))
+
+	if path.Base(slashPath) == GitDirName && isDir {
+		return true
+	}
+
+	result := false
+	dirPath := `.`
+	rest := slashPath
+	for {
+		// The patterns of deeper directories are checked later, so they take precedence.
+		ignored, matched := matchPatterns(m.rules[dirPath], rest, isDir)
+		if matched {
+			result = ignored
+		}
+
+		first, remainder, found := strings.Cut(rest, `/`)
+		if !found {
+			break
+		}
+
+		dirPath = path.Join(dirPath, first)
+		rest = remainder
+	}
+
+	return result
+}
+
+// ******** Private functions ********
+
+// addContent adds the patterns of the content of a ".gitignore" file that has been read with the given error.
+// A missing file is not an error.
+func (m *Matcher) addContent(dirPath string, content []byte, readErr error) error {
+	if readErr != nil {
+		if errors.Is(readErr, fs.ErrNotExist) {
+			return nil
+		}
+
+		return readErr
+	}
+
+	return m.Add(dirPath, bytes.NewReader(content))
+}
+
+// matchPatterns matches a path relative to the directory of the patterns.
+// It returns if the path is ignored and if any pattern matched.
+func matchPatterns(patterns []*pattern, relativePath string, isDir bool) (bool, bool) {
+	// The last matching pattern decides.
+	for i := len(patterns) - 1; i >= 0; i-- {
+		p := patterns[i]
+		if p.dirsOnly && !isDir {
+			continue
+		}
+
+		if p.re.MatchString(relativePath) {
+			return !p.negated, true
+		}
+	}
+
+	return false, false
+}
+
+// normalizeDir converts a directory path into the form that is used as the key of the rules.
+func normalizeDir(dirPath string) string {
+	return path.Clean(filepath.ToSlash(dirPath))
+}
+
+// parsePattern parses one line of a ".gitignore" file.
+// It returns nil, if the line does not contain a pattern.
+func parsePattern(line string) (*pattern, error) {
+	line = trimTrailingSpaces(line)
+	if len(line) == 0 || line[0] == '#' {
+		return nil, nil
+	}
+
+	result := &pattern{}
+
+	switch {
+	case line[0] == '!':
+		result.negated = true
+		line = line[1:]
+
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, `/`) {
+		result.dirsOnly = true
+		line = strings.TrimRight(line, `/`)
+	}
+
+	if len(line) == 0 {
+		return nil, nil
+	}
+
+	// A pattern with a separator at the beginning or in the middle is relative to the directory of the file.
+	anchored := strings.Contains(line, `/`)
+	line = strings.TrimPrefix(line, `/`)
+
+	expression, err := translatePattern(line)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := `^`
+	if runtime.GOOS == `windows` {
+		prefix = `(?i)^`
+	}
+
+	if !anchored {
+		prefix += `(?:.*/)?`
+	}
+
+	result.re, err = regexp.Compile(prefix + expression + `$`)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// trimTrailingSpaces removes trailing spaces that are not escaped with a backslash.
+func trimTrailingSpaces(line string) string {
+	end := len(line)
+	for end > 0 && line[end-1] == ' ' {
+		if end > 1 && line[end-2] == '\\' {
+			break
+		}
+
+		end--
+	}
+
+	return line[:end]
+}
+
+// translatePattern translates a pattern into a regular expression.
+func translatePattern(p string) (string, error) {
+	var sb strings.Builder
+
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch c {
+		case '*':
+			if strings.HasPrefix(p[i:], `**`) && (i == 0 || p[i-1] == '/') && (i+2 == len(p) || p[i+2] == '/') {
+				i += 2
+				if i == len(p) {
+					// A trailing "**" matches everything inside a directory.
+					sb.WriteString(`.*`)
+				} else {
+					// A "**/" matches zero or more directories.
+					sb.WriteString(`(?:.*/)?`)
+				}
+			} else {
+				sb.WriteString(`[^/]*`)
+			}
+
+		case '?':
+			sb.WriteString(`[^/]`)
+
+		case '[':
+			end := strings.IndexByte(p[i+1:], ']')
+			if end < 0 {
+				return ``, fmt.Errorf(`Missing ']' in '%s'`, p)
+			}
+
+			class := p[i+1 : i+1+end]
+			if strings.HasPrefix(class, `!`) {
+				class = `^` + class[1:]
+			}
+
+			sb.WriteString(`[` + strings.ReplaceAll(class, `\`, `\\`) + `]`)
+			i += end + 1
+
+		case '\\':
+			if i+1 < len(p) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(p[i : i+1]))
+			}
+
+		default:
+			sb.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+
+	return sb.String(), nil
+}
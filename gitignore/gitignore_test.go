@@ -0,0 +1,101 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package gitignore
+
+import (
+	"strings"
+	"testing"
+)
+
+// ******** Test functions ********
+
+const testPatterns = `# Comment
+*.log
+!keep.log
+build/
+/root.txt
+docs/**/generated
+tmp/**
+\#hash
+trailing\ 
+`
+
+func TestIsIgnored(t *testing.T) {
+	m := NewMatcher()
+	err := m.Add(`.`, strings.NewReader(testPatterns))
+	if err != nil {
+		t.Fatalf(`Could not parse patterns: %v`, err)
+	}
+
+	checkIgnored(t, m, `a.log`, false, true)
+	checkIgnored(t, m, `sub/a.log`, false, true)
+	checkIgnored(t, m, `sub/keep.log`, false, false)
+	checkIgnored(t, m, `build`, true, true)
+	checkIgnored(t, m, `sub/build`, true, true)
+	checkIgnored(t, m, `build`, false, false)
+	checkIgnored(t, m, `root.txt`, false, true)
+	checkIgnored(t, m, `sub/root.txt`, false, false)
+	checkIgnored(t, m, `docs/generated`, true, true)
+	checkIgnored(t, m, `docs/a/b/generated`, true, true)
+	checkIgnored(t, m, `other/generated`, true, false)
+	checkIgnored(t, m, `tmp/a/b.txt`, false, true)
+	checkIgnored(t, m, `#hash`, false, true)
+	checkIgnored(t, m, `trailing `, false, true)
+	checkIgnored(t, m, `.git`, true, true)
+	checkIgnored(t, m, `a.txt`, false, false)
+}
+
+func TestNestedFiles(t *testing.T) {
+	m := NewMatcher()
+	_ = m.Add(`.`, strings.NewReader("*.txt\n"))
+	_ = m.Add(`sub`, strings.NewReader("!important.txt\n/local.bin\n"))
+
+	checkIgnored(t, m, `a.txt`, false, true)
+	checkIgnored(t, m, `sub/a.txt`, false, true)
+	checkIgnored(t, m, `sub/important.txt`, false, false)
+	checkIgnored(t, m, `important.txt`, false, true)
+	checkIgnored(t, m, `sub/local.bin`, false, true)
+	checkIgnored(t, m, `sub/deeper/local.bin`, false, false)
+}
+
+func TestInvalidPattern(t *testing.T) {
+	err := NewMatcher().Add(`.`, strings.NewReader("[abc\n"))
+	if err == nil {
+		t.Fatal(`Invalid pattern not detected`)
+	}
+}
+
+// ******** Private functions ********
+
+// checkIgnored checks that a path is ignored or not.
+func checkIgnored(t *testing.T, m *Matcher, filePath string, isDir bool, expected bool) {
+	if m.IsIgnored(filePath, isDir) != expected {
+		t.Errorf(`Path '%s' (directory: %t) is ignored: %t`, filePath, isDir, !expected)
+	}
+}
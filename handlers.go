@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2025 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2025-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,15 +20,28 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.11.1
 //
 // Change history:
 //    2025-05-25: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Sign and verify archives.
+//    2026-10-19: V1.2.0: Pass command line objects to commands.
+//    2026-10-19: V1.3.0: Sign data from stdin.
+//    2026-10-19: V1.4.0: Get verification id from options.
+//    2026-10-19: V1.5.0: Add trust command and look up verification ids in the trust store.
+//    2026-10-19: V1.6.0: Add log command.
+//    2026-10-19: V1.7.0: Add revoke command.
+//    2026-10-19: V1.8.0: Print name of configuration file.
+//    2026-10-19: V1.9.0: Use verification id resolver of api package.
+//    2026-10-19: V1.10.0: Add selfcheck command.
+//    2026-10-19: V1.11.0: Add watch command.
+//    2026-10-19: V1.11.1: Do not run a command after its help has been printed.
 //
 
 package main
 
 import (
+	"filesigner/api"
 	"filesigner/cmdline"
 	"filesigner/logger"
 	"strings"
@@ -44,8 +57,8 @@ func handleSign(args []string) int {
 		return rcCommandLineError
 	}
 
-	rc := processCmdLineArguments(scl, args[1:])
-	if rc != rcOK {
+	rc, isHelp := processCmdLineArguments(scl, args[1:])
+	if isHelp || rc != rcOK {
 		return rc
 	}
 
@@ -53,24 +66,29 @@ func handleSign(args []string) int {
 		logger.SetLogLevel(logger.LogLevelWarning)
 	}
 
-	if len(scl.FileList) == 0 {
+	if len(scl.ConfigPath) != 0 {
+		logger.PrintInfof(handlerMsgBase+1, `Using configuration file '%s'`, scl.ConfigPath)
+	}
+
+	if len(scl.ArchivePath) == 0 && len(scl.StdinDataName) == 0 && len(scl.FileList) == 0 {
 		logger.PrintWarning(handlerMsgBase+0, `No files found to sign`)
 		return rcProcessWarning
 	}
 
-	return doSigning(scl.SignaturesFileName, scl.SignatureType, contextId, scl.BeQuiet, scl.FileList)
+	return doSigning(contextId, scl)
 }
 
 // handleVerify processes the "verify" command.
+// The verification id is optional, if it is specified by an option or looked up in the trust store.
 func handleVerify(args []string) int {
-	verificationId := strings.TrimSpace(args[0])
-	if len(verificationId) == 0 {
-		printEmptyArgument(`Verification id`)
-		return rcCommandLineError
+	verificationId, optionArgs, rc := splitVerificationId(args)
+	if rc != rcOK {
+		return rc
 	}
 
-	rc := processCmdLineArguments(vcl, args[1:])
-	if rc != rcOK {
+	var isHelp bool
+	rc, isHelp = processCmdLineArguments(vcl, optionArgs)
+	if isHelp || rc != rcOK {
 		return rc
 	}
 
@@ -78,24 +96,114 @@ func handleVerify(args []string) int {
 		logger.SetLogLevel(logger.LogLevelWarning)
 	}
 
-	return doVerification(vcl.SignaturesFileName, verificationId)
+	var resolver api.VerificationIdResolver
+	resolver, rc = makeVerificationIdResolver(verificationId, vcl)
+	if rc != rcOK {
+		return rc
+	}
+
+	return doVerification(resolver, vcl)
+}
+
+// handleWatch processes the "watch" command.
+// The verification id is optional, as for the "verify" command.
+func handleWatch(args []string) int {
+	verificationId, optionArgs, rc := splitVerificationId(args)
+	if rc != rcOK {
+		return rc
+	}
+
+	var isHelp bool
+	rc, isHelp = processCmdLineArguments(wcl, optionArgs)
+	if isHelp || rc != rcOK {
+		return rc
+	}
+
+	if wcl.BeQuiet {
+		logger.SetLogLevel(logger.LogLevelWarning)
+	}
+
+	var resolver api.VerificationIdResolver
+	resolver, rc = makeVerificationIdResolver(verificationId, &wcl.VerifyCommandLine)
+	if rc != rcOK {
+		return rc
+	}
+
+	return doWatch(resolver, wcl)
+}
+
+// handleTrust processes the "trust" command.
+func handleTrust(args []string) int {
+	rc, isHelp := processCmdLineArguments(tcl, args)
+	if isHelp || rc != rcOK {
+		return rc
+	}
+
+	return doTrust(tcl)
+}
+
+// handleLog processes the "log" command.
+func handleLog(args []string) int {
+	rc, isHelp := processCmdLineArguments(lcl, args)
+	if isHelp || rc != rcOK {
+		return rc
+	}
+
+	return doLogVerify(lcl)
+}
+
+// handleRevoke processes the "revoke" command.
+func handleRevoke(args []string) int {
+	rc, isHelp := processCmdLineArguments(rcl, args)
+	if isHelp || rc != rcOK {
+		return rc
+	}
+
+	return doRevoke(rcl)
+}
+
+// handleSelfCheck processes the "selfcheck" command.
+func handleSelfCheck(args []string) int {
+	rc, isHelp := processCmdLineArguments(sccl, args)
+	if isHelp || rc != rcOK {
+		return rc
+	}
+
+	return doSelfCheck(sccl)
+}
+
+// splitVerificationId splits the optional verification id from the options in the arguments.
+func splitVerificationId(args []string) (string, []string, int) {
+	if len(args) == 0 || strings.HasPrefix(args[0], `-`) {
+		return ``, args, rcOK
+	}
+
+	verificationId := strings.TrimSpace(args[0])
+	if len(verificationId) == 0 {
+		printEmptyArgument(`Verification id`)
+		return ``, nil, rcCommandLineError
+	}
+
+	return verificationId, args[1:], rcOK
 }
 
 // processCmdLineArguments processes a cmdline.CommandLiner.
-func processCmdLineArguments(cl cmdline.CommandLiner, args []string) int {
+// It returns true as the second value, if only the help has been requested and printed.
+// Then the command must not be run.
+func processCmdLineArguments(cl cmdline.CommandLiner, args []string) (int, bool) {
 	err, isHelp := cl.Parse(args)
 	if isHelp {
-		return rcOK
+		return rcOK, true
 	}
 	if err != nil {
-		return printCommandLineParsingError(err)
+		return printCommandLineParsingError(err), false
 	}
 
 	err = cl.ExtractCommandData()
 	if err != nil {
 		logger.PrintErrorf(mainMsgBase+2, `Error getting data from command line: %v`, err)
-		return rcProcessError
+		return rcProcessError, false
 	}
 
-	return rcOK
+	return rcOK, false
 }
@@ -20,7 +20,7 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.3.1
+// Version: 1.4.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
@@ -28,6 +28,7 @@
 //    2024-02-26: V1.2.0: Use a strengthened version of "Ed25519ph".
 //    2024-02-26: V1.3.0: Use a strengthened version of "Ed25519".
 //    2024-04-05: V1.3.1: Make type private, add validity check for PublicKey.
+//    2026-10-19: V1.4.0: Create signer from a seed.
 //
 
 package hashsignature
@@ -35,6 +36,7 @@ package hashsignature
 import (
 	"crypto/ed25519"
 	"filesigner/slicehelper"
+	"fmt"
 )
 
 // ******** Private types ********
@@ -64,6 +66,22 @@ func NewEd25519HashSigner() (HashSigner, error) {
 	return result, nil
 }
 
+// NewEd25519HashSignerFromSeed creates a new ed25519HashSigner with the private key of the supplied seed.
+// This is used for keys that have to be kept.
+func NewEd25519HashSignerFromSeed(seed []byte) (HashSigner, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf(`Seed has length %d instead of %d`, len(seed), ed25519.SeedSize)
+	}
+
+	privateKey := ed25519.NewKeyFromSeed(seed)
+
+	return &ed25519HashSigner{
+		signer:    privateKey,
+		publicKey: slicehelper.Copy(privateKey.Public().(ed25519.PublicKey)),
+		isValid:   true,
+	}, nil
+}
+
 // ******** Public functions ********
 
 // PublicKey returns a copy of the public key.
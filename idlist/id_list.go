@@ -0,0 +1,219 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Reject redirects that do not use "https".
+//
+
+// Package idlist implements reading of published lists of verification ids.
+//
+// An id list is a text file with one entry per line.
+// Each entry consists of a context id and a verification id, separated by white space.
+// Empty lines and lines that start with '#' are ignored.
+package idlist
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ******** Public types ********
+
+// IdList contains the verification ids for context ids.
+type IdList struct {
+	ids map[string][]string
+}
+
+// ******** Private constants ********
+
+// maxListSize is the maximum size of an id list.
+const maxListSize = 1 << 20
+
+// commentPrefix starts a comment line.
+const commentPrefix = `#`
+
+// maxRedirects is the maximum number of redirects that are followed, if the client does not limit them.
+const maxRedirects = 10
+
+// ******** Type creation ********
+
+// Load reads an id list from an "https" or "file" URL.
+// The client is used for "https" URLs. Redirects that do not use "https" are rejected.
+func Load(rawUrl string, client *http.Client) (*IdList, error) {
+	listUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, fmt.Errorf(`Invalid id list URL '%s': %w`, rawUrl, err)
+	}
+
+	var content []byte
+	switch strings.ToLower(listUrl.Scheme) {
+	case `https`:
+		content, err = readHttps(listUrl, client)
+
+	case `file`:
+		content, err = readFile(listUrl)
+
+	default:
+		return nil, fmt.Errorf(`Id list URL '%s' must have the scheme 'https' or 'file'`, rawUrl)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(content))
+}
+
+// Parse reads an id list from a reader.
+func Parse(r io.Reader) (*IdList, error) {
+	result := &IdList{ids: make(map[string][]string)}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, commentPrefix) {
+			continue
+		}
+
+		separatorIndex := strings.LastIndexAny(line, " \t")
+		if separatorIndex < 0 {
+			return nil, fmt.Errorf(`Line %d of id list does not contain a context id and a verification id`, lineNumber)
+		}
+
+		contextId := strings.TrimSpace(line[:separatorIndex])
+		verificationId := line[separatorIndex+1:]
+		result.ids[contextId] = append(result.ids[contextId], verificationId)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ******** Public functions ********
+
+// Ids returns the verification ids for a context id.
+// There may be more than one verification id, if files have been signed several times with the same context id.
+func (l *IdList) Ids(contextId string) []string {
+	return l.ids[contextId]
+}
+
+// ******** Private functions ********
+
+// readHttps reads the content of an "https" URL.
+func readHttps(listUrl *url.URL, client *http.Client) ([]byte, error) {
+	response, err := httpsOnlyClient(client).Get(listUrl.String())
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(`Could not get id list from '%s': %s`, listUrl.Redacted(), response.Status)
+	}
+
+	return readLimited(response.Body)
+}
+
+// httpsOnlyClient returns a copy of a client that rejects redirects that do not use "https",
+// so that the id list can not be replaced by a man in the middle.
+// The redirect check of the client is applied after this check.
+func httpsOnlyClient(client *http.Client) *http.Client {
+	checkRedirect := client.CheckRedirect
+
+	result := *client
+	result.CheckRedirect = func(request *http.Request, via []*http.Request) error {
+		if !strings.EqualFold(request.URL.Scheme, `https`) {
+			return fmt.Errorf(`Redirect to '%s' does not use 'https'`, request.URL.Redacted())
+		}
+
+		if checkRedirect != nil {
+			return checkRedirect(request, via)
+		}
+
+		if len(via) >= maxRedirects {
+			return errors.New(`Too many redirects`)
+		}
+
+		return nil
+	}
+
+	return &result
+}
+
+// readFile reads the content of a "file" URL.
+func readFile(listUrl *url.URL) ([]byte, error) {
+	if len(listUrl.Host) != 0 && listUrl.Host != `localhost` {
+		return nil, fmt.Errorf(`File URL '%s' must not have a remote host`, listUrl.String())
+	}
+
+	filePath := listUrl.Path
+
+	// A Windows path in a URL looks like "/C:/dir/file".
+	if len(filePath) > 2 && filePath[0] == '/' && filePath[2] == ':' {
+		filePath = filePath[1:]
+	}
+
+	f, err := os.Open(filepath.FromSlash(filePath))
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(f)
+
+	return readLimited(f)
+}
+
+// readLimited reads the content of a reader up to the maximum size of an id list.
+func readLimited(r io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxListSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) > maxListSize {
+		return nil, errors.New(`Id list is too large`)
+	}
+
+	return content, nil
+}
+
+// closeBody closes a reader. There is nothing sensible to do with an error here.
+func closeBody(c io.Closer) {
+	_ = c.Close()
+}
@@ -0,0 +1,191 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package idlist
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ******** Private constants ********
+
+// testList is the content of the test id list.
+const testList = `# Published verification ids
+release  ABCD-EFGH-JKLM-NPQR-STVW-XYZ0-12
+
+release ZYXW-VTSR-QPNM-LKJH-GFED-CBA9-87
+context with spaces	1234-5678-9ABC-DEFG-HJKM-NPQR-ST
+`
+
+// ******** Test functions ********
+
+func TestParse(t *testing.T) {
+	list, err := Parse(strings.NewReader(testList))
+	if err != nil {
+		t.Fatalf(`Could not parse list: %v`, err)
+	}
+
+	checkIds(t, list)
+
+	if len(list.Ids(`unknown`)) != 0 {
+		t.Fatal(`Ids for unknown context id found`)
+	}
+}
+
+func TestParseInvalidLine(t *testing.T) {
+	_, err := Parse(strings.NewReader("release\n"))
+	if err == nil {
+		t.Fatal(`Invalid line not detected`)
+	}
+}
+
+func TestLoadHttps(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != `/ids.txt` {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(testList))
+	}))
+	defer server.Close()
+
+	list, err := Load(server.URL+`/ids.txt`, server.Client())
+	if err != nil {
+		t.Fatalf(`Could not load list: %v`, err)
+	}
+
+	checkIds(t, list)
+
+	_, err = Load(server.URL+`/missing.txt`, server.Client())
+	if err == nil {
+		t.Fatal(`Missing list not detected`)
+	}
+}
+
+func TestLoadRedirect(t *testing.T) {
+	var plainRequested bool
+	plainServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		plainRequested = true
+		_, _ = w.Write([]byte(testList))
+	}))
+	defer plainServer.Close()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case `/ids.txt`:
+			_, _ = w.Write([]byte(testList))
+
+		case `/moved.txt`:
+			http.Redirect(w, r, `/ids.txt`, http.StatusMovedPermanently)
+
+		default:
+			http.Redirect(w, r, plainServer.URL+`/ids.txt`, http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	// Redirects within "https" are followed.
+	list, err := Load(server.URL+`/moved.txt`, server.Client())
+	if err != nil {
+		t.Fatalf(`Could not load redirected list: %v`, err)
+	}
+
+	checkIds(t, list)
+
+	// A redirect to plain "http" must be rejected.
+	_, err = Load(server.URL+`/insecure.txt`, server.Client())
+	if err == nil {
+		t.Fatal(`Redirect to plain http not rejected`)
+	}
+
+	if plainRequested {
+		t.Fatal(`Plain http server has been requested`)
+	}
+}
+
+func TestLoadUntrustedCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testList))
+	}))
+	defer server.Close()
+
+	_, err := Load(server.URL, &http.Client{})
+	if err == nil {
+		t.Fatal(`Untrusted certificate not detected`)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	listPath := filepath.Join(t.TempDir(), `ids.txt`)
+	err := os.WriteFile(listPath, []byte(testList), 0600)
+	if err != nil {
+		t.Fatalf(`Could not write list: %v`, err)
+	}
+
+	var list *IdList
+	list, err = Load(`file://`+filepath.ToSlash(listPath), nil)
+	if err != nil {
+		t.Fatalf(`Could not load list: %v`, err)
+	}
+
+	checkIds(t, list)
+}
+
+func TestLoadInvalidScheme(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testList))
+	}))
+	defer server.Close()
+
+	_, err := Load(server.URL, server.Client())
+	if err == nil {
+		t.Fatal(`Plain http URL not rejected`)
+	}
+}
+
+// ******** Private functions ********
+
+// checkIds checks that the list contains the ids of the test list.
+func checkIds(t *testing.T, list *IdList) {
+	ids := list.Ids(`release`)
+	if !slices.Equal(ids, []string{`ABCD-EFGH-JKLM-NPQR-STVW-XYZ0-12`, `ZYXW-VTSR-QPNM-LKJH-GFED-CBA9-87`}) {
+		t.Fatalf(`Wrong ids for 'release': %v`, ids)
+	}
+
+	ids = list.Ids(`context with spaces`)
+	if !slices.Equal(ids, []string{`1234-5678-9ABC-DEFG-HJKM-NPQR-ST`}) {
+		t.Fatalf(`Wrong ids for 'context with spaces': %v`, ids)
+	}
+}
@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,11 +20,14 @@
 //
 // Author: Frank Schwab
 //
-// Version: 2.0.0
+// Version: 2.3.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
 //    2025-05-23: V2.0.0: Functions can be called with multiple byte slices.
+//    2026-10-19: V2.1.0: Add word form of key ids.
+//    2026-10-19: V2.2.0: Add check code to key ids.
+//    2026-10-19: V2.3.0: Make KeyHash safe for concurrent use.
 //
 
 package keyid
@@ -32,30 +35,40 @@ package keyid
 import (
 	"crypto/subtle"
 	"filesigner/base32encoding"
+	"filesigner/wordencoding"
+	"fmt"
 	"golang.org/x/crypto/sha3"
+	"strings"
+	"unicode"
 )
 
 // ******** Private constants ********
 
+// keyHashSize is the size of a key hash in bytes.
+const keyHashSize = 16
+
+// legacyKeyIdLength is the number of characters without separators of a key id without check code.
+const legacyKeyIdLength = (keyHashSize*8 + 4) / 5
+
+// maxKeyGroupSize is the maximum length of a group in a key id.
+// Key ids in word form contain longer groups.
+const maxKeyGroupSize = 4
+
 // beginFence is the first bytes of the fence.
 var beginFence = []byte{'k', 'e', 'y', 0x5a}
 
 // endFence is the last bytes of the fence.
 var endFence = []byte{0xa5, 'h', 's', 'h'}
 
-// ******** Private variables ********
-
-// hasher is the Shake-128 hasher.
-var hasher = sha3.NewShake128()
-
-// singleByte is a byte slice with a single element used for hashing integers.
-var singleByte = make([]byte, 1)
-
 // ******** Public functions ********
 
 // KeyHash calculates the Shake-128 hash of a slice of byte slices.
+// It is safe for concurrent use.
 func KeyHash(s ...[]byte) []byte {
-	hasher.Reset()
+	hasher := sha3.NewShake128()
+
+	// singleByte is a byte slice with a single element used for hashing integers.
+	singleByte := make([]byte, 1)
 
 	_, _ = hasher.Write(beginFence)
 	for i, b := range s {
@@ -77,13 +90,71 @@ func KeyHash(s ...[]byte) []byte {
 	_, _ = hasher.Read(rawResult)
 
 	// Xor upper and lower half of hash result as the final result.
-	result := make([]byte, 16)
+	result := make([]byte, keyHashSize)
 	subtle.XORBytes(result, rawResult[:16], rawResult[16:])
 
 	return result
 }
 
 // KeyId returns the key id of some key bytes.
+// The key id contains a check code, so typos can be detected.
 func KeyId(key ...[]byte) string {
-	return base32encoding.EncodeKey(KeyHash(key...))
+	return base32encoding.EncodeKeyWithCheck(KeyHash(key...))
+}
+
+// KeyWords returns the key id of some key bytes in word form.
+func KeyWords(key ...[]byte) string {
+	return wordencoding.Encode(KeyHash(key...))
+}
+
+// KeyHashFromId returns the key hash of a key id.
+// The key id may be given either in the form returned by KeyId or in the word form returned by KeyWords.
+// Key ids without check code, that were created by earlier versions, are accepted, as well.
+func KeyHashFromId(keyId string) ([]byte, error) {
+	var result []byte
+	var err error
+
+	if isWordForm(keyId) {
+		result, err = wordencoding.Decode(keyId)
+	} else {
+		normalizedKeyId := strings.ToUpper(strings.TrimSpace(keyId))
+		if isLegacyKeyId(normalizedKeyId) {
+			result, err = base32encoding.DecodeKey(normalizedKeyId)
+		} else {
+			result, err = base32encoding.DecodeKeyWithCheck(normalizedKeyId)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) != keyHashSize {
+		return nil, fmt.Errorf(`Key id has wrong length`)
+	}
+
+	return result, nil
+}
+
+// ******** Private functions ********
+
+// isWordForm checks if a key id is in word form.
+func isWordForm(keyId string) bool {
+	for _, group := range strings.FieldsFunc(keyId, isGroupSeparator) {
+		if len(group) > maxKeyGroupSize {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isLegacyKeyId checks if a key id has been created without a check code.
+func isLegacyKeyId(keyId string) bool {
+	return len(keyId)-strings.Count(keyId, `-`) == legacyKeyIdLength
+}
+
+// isGroupSeparator checks if a rune separates the groups of a key id.
+func isGroupSeparator(r rune) bool {
+	return r == '-' || unicode.IsSpace(r)
 }
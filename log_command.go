@@ -0,0 +1,96 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package main
+
+import (
+	"errors"
+	"filesigner/cmdline"
+	"filesigner/logger"
+	"filesigner/signaturelog"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// ******** Private functions ********
+
+// doLogVerify checks the hash chain of a signature log and prints the entries with the given verification id.
+func doLogVerify(lcl *cmdline.LogCommandLine) int {
+	var signatureLog *signaturelog.Log
+
+	// A missing log is an error here, as Load treats it as an empty log.
+	_, err := os.Stat(lcl.LogPath)
+	if err == nil {
+		signatureLog, err = signaturelog.Load(lcl.LogPath)
+	}
+
+	if err != nil {
+		var chainErr *signaturelog.ChainError
+		switch {
+		case errors.As(err, &chainErr):
+			logger.PrintErrorf(logCmdMsgBase+0, `Signature log '%s' has been modified in line %d: %s`, lcl.LogPath, chainErr.Line, chainErr.Reason)
+
+		case errors.Is(err, fs.ErrNotExist):
+			logger.PrintErrorf(logCmdMsgBase+1, `Signature log '%s' does not exist`, lcl.LogPath)
+
+		default:
+			logger.PrintErrorf(logCmdMsgBase+2, `Could not read signature log '%s': %v`, lcl.LogPath, err)
+		}
+
+		return rcProcessError
+	}
+
+	logger.PrintInfof(logCmdMsgBase+3, `Hash chain of signature log '%s' is intact. Number of entries: %d`, lcl.LogPath, len(signatureLog.Entries()))
+
+	if len(lcl.VerificationId) == 0 {
+		return rcOK
+	}
+
+	return printLogEntries(signatureLog, lcl.VerificationId)
+}
+
+// printLogEntries prints the entries of a signature log with the given verification id.
+func printLogEntries(signatureLog *signaturelog.Log, verificationId string) int {
+	entries, err := signatureLog.Find(verificationId)
+	if err != nil {
+		printInvalidVerificationId(verificationId, err)
+		return rcProcessError
+	}
+
+	if len(entries) == 0 {
+		logger.PrintWarningf(logCmdMsgBase+4, `Verification id '%s' is not in the signature log`, verificationId)
+		return rcProcessWarning
+	}
+
+	for _, e := range entries {
+		fmt.Printf("%d  %s  %s  %s  %s  %s\n", e.Sequence, e.Timestamp, e.ContextId, e.Hostname, e.VerificationId, e.SignatureDigest)
+	}
+
+	return rcOK
+}
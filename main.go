@@ -61,15 +61,21 @@ const (
 	rcCommandLineError = 1
 	rcProcessWarning   = 2
 	rcProcessError     = 3
+	rcRevoked          = 4
 )
 
 // -------- Command verbs --------
 
 const (
-	commandHelp    = `help`
-	commandSign    = `sign`
-	commandVerify  = `verify`
-	commandVersion = `version`
+	commandHelp      = `help`
+	commandLog       = `log`
+	commandRevoke    = `revoke`
+	commandSelfCheck = `selfcheck`
+	commandSign      = `sign`
+	commandTrust     = `trust`
+	commandVerify    = `verify`
+	commandVersion   = `version`
+	commandWatch     = `watch`
 )
 
 // ******** More private variables ********
@@ -82,6 +88,21 @@ var scl = cmdline.NewSignCommandLine()
 // vcl contains the command line interpreter for the "verify" command.
 var vcl = cmdline.NewVerifyCommandLine()
 
+// tcl contains the command line interpreter for the "trust" command.
+var tcl = cmdline.NewTrustCommandLine()
+
+// lcl contains the command line interpreter for the "log" command.
+var lcl = cmdline.NewLogCommandLine()
+
+// rcl contains the command line interpreter for the "revoke" command.
+var rcl = cmdline.NewRevokeCommandLine()
+
+// sccl contains the command line interpreter for the "selfcheck" command.
+var sccl = cmdline.NewSelfCheckCommandLine()
+
+// wcl contains the command line interpreter for the "watch" command.
+var wcl = cmdline.NewWatchCommandLine()
+
 // ******** Real main function ********
 
 // mainWithReturnCode is the real main function with arguments and return code.
@@ -106,10 +127,28 @@ func mainWithReturnCode(args []string) int {
 		return handleSign(args[1:])
 
 	case commandVerify:
+		return handleVerify(args[1:])
+
+	case commandTrust:
 		if len(args) < 2 {
-			return printMissingArgument(`Verification id`)
+			return printMissingArgument(`Trust subcommand`)
 		}
-		return handleVerify(args[1:])
+		return handleTrust(args[1:])
+
+	case commandLog:
+		if len(args) < 2 {
+			return printMissingArgument(`Log subcommand`)
+		}
+		return handleLog(args[1:])
+
+	case commandRevoke:
+		return handleRevoke(args[1:])
+
+	case commandWatch:
+		return handleWatch(args[1:])
+
+	case commandSelfCheck:
+		return handleSelfCheck(args[1:])
 
 	case commandVersion:
 		return printVersion()
@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2025 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2025-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,10 +20,22 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.12.0
 //
 // Change history:
 //    2025-05-25: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Add message base for verification ids.
+//    2026-10-19: V1.2.0: Add message base for trust command.
+//    2026-10-19: V1.3.0: Add message base for log command.
+//    2026-10-19: V1.4.0: Add message base for revoke command.
+//    2026-10-19: V1.5.0: Add message base for signature times.
+//    2026-10-19: V1.6.0: Add message base for file metadata.
+//    2026-10-19: V1.7.0: Add message base for symbolic links.
+//    2026-10-19: V1.8.0: Use message bases of removed files for verification results.
+//    2026-10-19: V1.9.0: Add message base for selfcheck command.
+//    2026-10-19: V1.10.0: Add message base for watch command.
+//    2026-10-19: V1.11.0: Add message base for notifications.
+//    2026-10-19: V1.12.0: Add message base for metrics.
 //
 
 package main
@@ -51,3 +63,47 @@ const errorMsgBase = 70
 // handlerMsgBase is the base number for all messages in handlers.
 // Reserved numbers are 80-89.
 const handlerMsgBase = 80
+
+// verificationIdMsgBase is the base number for all messages in verification_id.
+// Reserved numbers are 90-99.
+const verificationIdMsgBase = 90
+
+// trustCmdMsgBase is the base number for all messages in trust_command.
+// Reserved numbers are 100-109.
+const trustCmdMsgBase = 100
+
+// logCmdMsgBase is the base number for all messages in log_command.
+// Reserved numbers are 110-119.
+const logCmdMsgBase = 110
+
+// revokeCmdMsgBase is the base number for all messages in revoke_command.
+// Reserved numbers are 120-139.
+const revokeCmdMsgBase = 120
+
+// signatureTimeMsgBase is the base number for all messages about signature times.
+// Reserved numbers are 140-149.
+const signatureTimeMsgBase = 140
+
+// fileMetadataMsgBase is the base number for all messages about file metadata.
+// Reserved numbers are 150-159.
+const fileMetadataMsgBase = 150
+
+// symlinksMsgBase is the base number for all messages about symbolic links.
+// Reserved numbers are 160-169.
+const symlinksMsgBase = 160
+
+// selfCheckMsgBase is the base number for all messages in selfcheck_command.
+// Reserved numbers are 170-179.
+const selfCheckMsgBase = 170
+
+// watchCmdMsgBase is the base number for all messages in watch_command.
+// Reserved numbers are 180-189.
+const watchCmdMsgBase = 180
+
+// notificationMsgBase is the base number for all messages about notifications.
+// Reserved numbers are 190-199.
+const notificationMsgBase = 190
+
+// metricsMsgBase is the base number for all messages about metrics.
+// Reserved numbers are 200-209.
+const metricsMsgBase = 200
@@ -0,0 +1,130 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Write metrics of more than one signatures file.
+//
+
+package main
+
+import (
+	"filesigner/api"
+	"filesigner/logger"
+	"filesigner/metrics"
+	"path/filepath"
+)
+
+// ******** Private types ********
+
+// metricDefinition defines a metric of a verification and how its value is taken from the result.
+type metricDefinition struct {
+	name     string
+	help     string
+	getValue func(result *verificationResult) float64
+}
+
+// ******** Private constants ********
+
+// metricsPrefix is the prefix of the names of all metrics of a verification.
+const metricsPrefix = `filesigner_verify_`
+
+// ******** Private variables ********
+
+// metricDefinitions contains the metrics that are written for each signatures file.
+// If the signatures file could not be verified, the report is nil and the file counts are 0.
+var metricDefinitions = []*metricDefinition{
+	{`last_run_timestamp_seconds`, `Time of the last verification as a Unix timestamp.`, func(r *verificationResult) float64 {
+		return float64(r.startTime.UnixMilli()) / 1000
+	}},
+	{`files_verified`, `Number of files that have been verified successfully.`, func(r *verificationResult) float64 {
+		return reportCount(r, (*api.Report).SuccessCount)
+	}},
+	{`files_modified`, `Number of files that have been modified or could not be verified.`, func(r *verificationResult) float64 {
+		return reportCount(r, (*api.Report).ErrorCount)
+	}},
+	{`files_missing`, `Number of files that are missing.`, func(r *verificationResult) float64 {
+		return reportCount(r, (*api.Report).MissingCount)
+	}},
+	{`bytes_hashed`, `Number of bytes that have been hashed.`, func(r *verificationResult) float64 {
+		if r.report == nil {
+			return 0
+		}
+
+		return float64(r.report.BytesHashed)
+	}},
+	{`duration_seconds`, `Duration of the verification in seconds.`, func(r *verificationResult) float64 {
+		return r.duration.Seconds()
+	}},
+	{`return_code`, `Return code of the verification.`, func(r *verificationResult) float64 {
+		return float64(r.rc)
+	}},
+}
+
+// ******** Private functions ********
+
+// writeMetrics writes the metrics of the verifications of the signatures files to the metrics file.
+// A metrics file that can not be written is reported, but does not change the return code.
+func writeMetrics(metricsFilePath string, results []*verificationResult) {
+	labelSets := make([][]metrics.Label, len(results))
+	for i, result := range results {
+		var contextId string
+		if result.report != nil {
+			contextId = result.report.ContextId
+		}
+
+		labelSets[i] = []metrics.Label{
+			{Name: `context_id`, Value: contextId},
+			{Name: `signatures_file`, Value: filepath.ToSlash(result.signaturesFilePath)},
+		}
+	}
+
+	gauges := make([]*metrics.Gauge, len(metricDefinitions))
+	for i, md := range metricDefinitions {
+		gauge := &metrics.Gauge{Name: metricsPrefix + md.name, Help: md.help, Samples: make([]*metrics.Sample, len(results))}
+		for j, result := range results {
+			gauge.Samples[j] = &metrics.Sample{Labels: labelSets[j], Value: md.getValue(result)}
+		}
+
+		gauges[i] = gauge
+	}
+
+	err := metrics.WriteTextFile(metricsFilePath, gauges)
+	if err != nil {
+		logger.PrintErrorf(metricsMsgBase+0, `Could not write metrics file '%s': %v`, metricsFilePath, err)
+		return
+	}
+
+	logger.PrintInfof(metricsMsgBase+1, `Metrics have been written to '%s'`, metricsFilePath)
+}
+
+// reportCount returns a count of the report of a verification result as a metric value.
+// It is 0, if the signatures file could not be verified.
+func reportCount(result *verificationResult, count func(*api.Report) int) float64 {
+	if result.report == nil {
+		return 0
+	}
+
+	return float64(count(result.report))
+}
@@ -0,0 +1,128 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.1
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Gauges with more than one sample.
+//    2026-10-19: V1.1.1: Write text file with filehelper.WriteFileAtomic.
+//
+
+// Package metrics writes gauges in the Prometheus text format,
+// so that they can be collected by the textfile collector of a node exporter.
+package metrics
+
+import (
+	"filesigner/filehelper"
+	"strconv"
+	"strings"
+)
+
+// ******** Public types ********
+
+// Label is a label of a gauge.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is a value of a gauge with its labels.
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+// Gauge is a metric with one sample for each set of labels.
+type Gauge struct {
+	Name    string
+	Help    string
+	Samples []*Sample
+}
+
+// ******** Private variables ********
+
+// labelValueEscaper escapes the characters that are not permitted in label values.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// helpEscaper escapes the characters that are not permitted in help texts.
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// ******** Public functions ********
+
+// Format returns the gauges in the Prometheus text format.
+func Format(gauges []*Gauge) string {
+	var sb strings.Builder
+	for _, g := range gauges {
+		sb.WriteString(`# HELP `)
+		sb.WriteString(g.Name)
+		sb.WriteByte(' ')
+		sb.WriteString(helpEscaper.Replace(g.Help))
+		sb.WriteByte('\n')
+
+		sb.WriteString(`# TYPE `)
+		sb.WriteString(g.Name)
+		sb.WriteString(" gauge\n")
+
+		for _, s := range g.Samples {
+			sb.WriteString(g.Name)
+			sb.WriteString(formatLabels(s.Labels))
+			sb.WriteByte(' ')
+			sb.WriteString(strconv.FormatFloat(s.Value, 'g', -1, 64))
+			sb.WriteByte('\n')
+		}
+	}
+
+	return sb.String()
+}
+
+// WriteTextFile writes the gauges to a file in the Prometheus text format.
+// The file is written atomically, so that a collector never reads an incomplete file.
+// The temporary file ends with ".tmp", so that it is not collected.
+func WriteTextFile(filePath string, gauges []*Gauge) error {
+	return filehelper.WriteFileAtomic(filePath, []byte(Format(gauges)), 0644)
+}
+
+// ******** Private functions ********
+
+// formatLabels returns the labels in the Prometheus text format.
+func formatLabels(labels []Label) string {
+	if len(labels) == 0 {
+		return ``
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, l := range labels {
+		if i != 0 {
+			sb.WriteByte(',')
+		}
+
+		sb.WriteString(l.Name)
+		sb.WriteString(`="`)
+		sb.WriteString(labelValueEscaper.Replace(l.Value))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ******** Test functions ********
+
+func TestFormat(t *testing.T) {
+	labelsA := []Label{
+		{Name: `context_id`, Value: `a "quoted" \ id`},
+		{Name: `signatures_file`, Value: "x\ny"},
+	}
+	labelsB := []Label{{Name: `context_id`, Value: `b`}}
+	gauges := []*Gauge{
+		{Name: `test_files`, Help: `Number of files`, Samples: []*Sample{{Labels: labelsA, Value: 3}, {Labels: labelsB, Value: 4}}},
+		{Name: `test_seconds`, Help: `Duration`, Samples: []*Sample{{Labels: labelsA, Value: 0.25}}},
+	}
+
+	expected := `# HELP test_files Number of files
+# TYPE test_files gauge
+test_files{context_id="a \"quoted\" \\ id",signatures_file="x\ny"} 3
+test_files{context_id="b"} 4
+# HELP test_seconds Duration
+# TYPE test_seconds gauge
+test_seconds{context_id="a \"quoted\" \\ id",signatures_file="x\ny"} 0.25
+`
+
+	text := Format(gauges)
+	if text != expected {
+		t.Fatalf("Format returned\n%s\ninstead of\n%s", text, expected)
+	}
+}
+
+func TestFormatWithoutLabels(t *testing.T) {
+	text := Format([]*Gauge{{Name: `test_big`, Help: `Big`, Samples: []*Sample{{Value: 1760000000}}}})
+	if text != "# HELP test_big Big\n# TYPE test_big gauge\ntest_big 1.76e+09\n" {
+		t.Fatalf(`Wrong text: %q`, text)
+	}
+}
+
+func TestWriteTextFile(t *testing.T) {
+	dirPath := t.TempDir()
+	filePath := filepath.Join(dirPath, `test.prom`)
+
+	gauges := []*Gauge{{Name: `test_rc`, Help: `Return code`, Samples: []*Sample{{Value: 1}}}}
+	for i := 0; i < 2; i++ {
+		err := WriteTextFile(filePath, gauges)
+		if err != nil {
+			t.Fatalf(`Could not write text file: %v`, err)
+		}
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf(`Could not read text file: %v`, err)
+	}
+
+	if string(content) != Format(gauges) {
+		t.Fatalf(`Wrong content: %q`, content)
+	}
+
+	// No temporary files must be left over.
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(dirPath)
+	if err != nil {
+		t.Fatalf(`Could not read directory: %v`, err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf(`%d files in directory instead of 1`, len(entries))
+	}
+}
@@ -0,0 +1,111 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Send notification for a signatures file path.
+//
+
+package main
+
+import (
+	"filesigner/api"
+	"filesigner/cmdline"
+	"filesigner/logger"
+	"filesigner/notification"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ******** Private constants ********
+
+// notificationTimeout is the time after which an attempt to send a notification is aborted.
+const notificationTimeout = 10 * time.Second
+
+// ******** Private functions ********
+
+// sendNotification sends a signed summary of an unsuccessful verification of a signatures file to the notification URL.
+// If the signatures file could not be verified, the report is nil and verifyErr describes the problem.
+// A notification that can not be sent is reported, but does not change the return code.
+func sendNotification(vcl *cmdline.VerifyCommandLine, signaturesFilePath string, report *api.Report, rc int, verifyErr error) {
+	key := os.Getenv(vcl.NotifyKeyEnv)
+	if len(key) == 0 {
+		logger.PrintErrorf(notificationMsgBase+0, `Environment variable '%s' does not contain a notification key`, vcl.NotifyKeyEnv)
+		return
+	}
+
+	sender, err := notification.NewSender(vcl.NotifyUrl, []byte(key), &http.Client{Timeout: notificationTimeout})
+	if err != nil {
+		logger.PrintErrorf(notificationMsgBase+1, `Could not create notification: %v`, err)
+		return
+	}
+
+	err = sender.Send(makeSummary(signaturesFilePath, report, rc, verifyErr))
+	if err != nil {
+		logger.PrintError(notificationMsgBase+2, err.Error())
+		return
+	}
+
+	logger.PrintInfof(notificationMsgBase+3, `Notification has been sent to '%s'`, vcl.NotifyUrl)
+}
+
+// makeSummary creates the summary of a verification.
+func makeSummary(signaturesFileName string, report *api.Report, rc int, verifyErr error) *notification.Summary {
+	result := &notification.Summary{
+		Timestamp:          time.Now().Format(time.RFC3339),
+		SignaturesFileName: signaturesFileName,
+		ReturnCode:         rc,
+	}
+
+	// The host name is only informational, so it is left empty, if it can not be determined.
+	result.Hostname, _ = os.Hostname()
+
+	if report == nil {
+		if verifyErr != nil {
+			result.Error = verifyErr.Error()
+		}
+
+		return result
+	}
+
+	result.ContextId = report.ContextId
+	result.VerificationId = report.VerificationId
+
+	for _, warning := range report.Warnings {
+		result.Warnings = append(result.Warnings, warning.Error())
+	}
+
+	for _, fr := range report.Files {
+		if fr.Status != api.FileStatusOK {
+			result.FailingFiles = append(result.FailingFiles, &notification.FailingFile{
+				Path:   fr.FilePath,
+				Status: fr.Status.String(),
+				Error:  fr.Err.Error(),
+			})
+		}
+	}
+
+	return result
+}
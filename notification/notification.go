@@ -0,0 +1,199 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.1
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.0.1: Do not follow redirects.
+//
+
+// Package notification implements sending signed summaries of verifications to HTTP endpoints.
+//
+// A summary is sent as a JSON object in the body of a POST request.
+// The body is authenticated with an HMAC-SHA256 over the body with a shared key,
+// which is sent hex-encoded in the header "X-Filesigner-Signature" with the prefix "sha256=".
+package notification
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ******** Public types ********
+
+// Summary is the summary of a verification that is sent.
+// Error describes why the signatures file could not be verified. Then there are no failing files.
+type Summary struct {
+	Timestamp          string         `json:"timestamp"`
+	Hostname           string         `json:"hostname"`
+	SignaturesFileName string         `json:"signaturesFile"`
+	ContextId          string         `json:"contextId,omitempty"`
+	VerificationId     string         `json:"verificationId,omitempty"`
+	ReturnCode         int            `json:"returnCode"`
+	Error              string         `json:"error,omitempty"`
+	Warnings           []string       `json:"warnings,omitempty"`
+	FailingFiles       []*FailingFile `json:"failingFiles,omitempty"`
+}
+
+// FailingFile describes a file whose verification has failed.
+type FailingFile struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// Sender sends summaries to an HTTP endpoint.
+type Sender struct {
+	url        string
+	key        []byte
+	client     *http.Client
+	retryDelay time.Duration
+}
+
+// ******** Public constants ********
+
+// SignatureHeader is the name of the header that contains the signature of the body.
+const SignatureHeader = `X-Filesigner-Signature`
+
+// SignaturePrefix is the prefix of the hex-encoded signature in the signature header.
+const SignaturePrefix = `sha256=`
+
+// ******** Private constants ********
+
+// maxAttempts is the maximum number of attempts to send a summary.
+const maxAttempts = 3
+
+// defaultRetryDelay is the delay before the first retry. It is doubled for every further retry.
+const defaultRetryDelay = 2 * time.Second
+
+// maxErrorBodySize is the maximum number of bytes of a response body that are read.
+const maxErrorBodySize = 4096
+
+// ******** Type creation ********
+
+// NewSender creates a sender for an endpoint URL that signs the summaries with the given key.
+// A copy of the client is used for the requests, so its timeout is the timeout of each attempt.
+// Redirects are not followed, as a client would send the summary with a GET request without a body.
+func NewSender(url string, key []byte, client *http.Client) (*Sender, error) {
+	if len(key) == 0 {
+		return nil, errors.New(`Notification key must not be empty`)
+	}
+
+	noRedirectClient := *client
+	noRedirectClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &Sender{
+		url:        url,
+		key:        key,
+		client:     &noRedirectClient,
+		retryDelay: defaultRetryDelay,
+	}, nil
+}
+
+// ******** Public functions ********
+
+// Send sends a summary to the endpoint.
+// Sending is retried if the endpoint can not be reached or reports a temporary problem.
+func (s *Sender) Send(summary *Summary) error {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf(`Could not convert summary to JSON: %w`, err)
+	}
+
+	signature := SignaturePrefix + hex.EncodeToString(Sign(s.key, body))
+
+	delay := s.retryDelay
+	for attempt := 1; ; attempt++ {
+		var isTemporary bool
+		isTemporary, err = s.post(body, signature)
+		if err == nil {
+			return nil
+		}
+
+		if !isTemporary || attempt == maxAttempts {
+			return fmt.Errorf(`Could not send notification to '%s' in %d attempt(s): %w`, s.url, attempt, err)
+		}
+
+		time.Sleep(delay)
+		delay <<= 1
+	}
+}
+
+// Sign returns the HMAC-SHA256 of a body with a key.
+func Sign(key []byte, body []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+
+	return mac.Sum(nil)
+}
+
+// ******** Private functions ********
+
+// post sends a body with its signature to the endpoint.
+// If it fails, it returns if the problem may be temporary.
+func (s *Sender) post(body []byte, signature string) (bool, error) {
+	request, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	request.Header.Set(`Content-Type`, `application/json`)
+	request.Header.Set(SignatureHeader, signature)
+
+	var response *http.Response
+	response, err = s.client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer closeBody(response.Body)
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+
+	if response.StatusCode >= 300 && response.StatusCode < 400 {
+		return false, fmt.Errorf(`Endpoint returned '%s' with a redirect to '%s', which is not followed`,
+			response.Status,
+			response.Header.Get(`Location`))
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+	err = fmt.Errorf(`Endpoint returned '%s': %s`, response.Status, bytes.TrimSpace(message))
+
+	return response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests, err
+}
+
+// closeBody closes a response body and ignores any error, as there is nothing that can be done about it.
+func closeBody(c io.Closer) {
+	_ = c.Close()
+}
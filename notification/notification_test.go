@@ -0,0 +1,171 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Redirect.
+//
+
+package notification
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ******** Private constants ********
+
+// testKey is the key that the test summaries are signed with.
+const testKey = `notification test key`
+
+// ******** Test functions ********
+
+func TestSend(t *testing.T) {
+	var received *Summary
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature := r.Header.Get(SignatureHeader)
+		if signature != SignaturePrefix+hex.EncodeToString(Sign([]byte(testKey), body)) {
+			http.Error(w, `Invalid signature`, http.StatusUnauthorized)
+			return
+		}
+
+		received = &Summary{}
+		_ = json.Unmarshal(body, received)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(t, server.URL)
+
+	summary := &Summary{
+		VerificationId: `NQM1-B6NM-GHX0-T2G3-99HC-W713-NMFG`,
+		ReturnCode:     3,
+		FailingFiles:   []*FailingFile{{Path: `a.txt`, Status: `modified`, Error: `File 'a.txt' has been modified`}},
+	}
+
+	err := sender.Send(summary)
+	if err != nil {
+		t.Fatalf(`Could not send summary: %v`, err)
+	}
+
+	if received == nil || received.VerificationId != summary.VerificationId || len(received.FailingFiles) != 1 {
+		t.Fatalf(`Wrong summary received: %v`, received)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) < maxAttempts {
+			http.Error(w, `Try again`, http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	err := newTestSender(t, server.URL).Send(&Summary{})
+	if err != nil {
+		t.Fatalf(`Sending with retries failed: %v`, err)
+	}
+
+	if count.Load() != maxAttempts {
+		t.Fatalf(`%d attempts instead of %d`, count.Load(), maxAttempts)
+	}
+}
+
+func TestNoRetry(t *testing.T) {
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		http.Error(w, `Not here`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := newTestSender(t, server.URL).Send(&Summary{})
+	if err == nil || !strings.Contains(err.Error(), `Not here`) {
+		t.Fatalf(`Permanent error not reported: %v`, err)
+	}
+
+	if count.Load() != 1 {
+		t.Fatalf(`Permanent error has been retried %d times`, count.Load()-1)
+	}
+}
+
+func TestRedirect(t *testing.T) {
+	var targetCount atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		targetCount.Add(1)
+	}))
+	defer target.Close()
+
+	for _, status := range []int{http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect} {
+		var count atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count.Add(1)
+			http.Redirect(w, r, target.URL, status)
+		}))
+
+		err := newTestSender(t, server.URL).Send(&Summary{})
+		server.Close()
+
+		if err == nil || !strings.Contains(err.Error(), `redirect`) {
+			t.Fatalf(`Redirect with status %d not reported: %v`, status, err)
+		}
+
+		if count.Load() != 1 {
+			t.Fatalf(`Redirect with status %d has been retried %d times`, status, count.Load()-1)
+		}
+	}
+
+	if targetCount.Load() != 0 {
+		t.Fatalf(`Redirect has been followed %d times`, targetCount.Load())
+	}
+}
+
+func TestEmptyKey(t *testing.T) {
+	_, err := NewSender(`http://localhost`, nil, http.DefaultClient)
+	if err == nil {
+		t.Fatal(`Empty key not detected`)
+	}
+}
+
+// ******** Private functions ********
+
+// newTestSender creates a sender for a test server that does not wait long before retries.
+func newTestSender(t *testing.T, url string) *Sender {
+	result, err := NewSender(url, []byte(testKey), &http.Client{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf(`Could not create sender: %v`, err)
+	}
+
+	result.retryDelay = time.Millisecond
+
+	return result
+}
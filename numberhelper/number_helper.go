@@ -20,11 +20,12 @@
 //
 // Author: Frank Schwab
 //
-// Version: 2.0.0
+// Version: 3.0.0
 //
 // Change history:
 //    2024-02-15: V1.0.0: Created.
 //    2024-03-05: V2.0.0: Added 64 bit methods.
+//    2026-10-19: V3.0.0: Remove methods with static buffers, as they are not safe for concurrent use.
 //
 
 package numberhelper
@@ -63,23 +64,6 @@ func BigEndianBytesAsUint32(slice []byte) uint32 {
 	return result
 }
 
-// static32Buffer is the static buffer that contains a converted uint32.
-var static32Buffer = [4]byte{}
-
-// StaticIntAsShortestBigEndianBytes returns an int as the shortest possible byte slice in big endian byte order.
-// It uses a static buffer, so that the content of the buffer changes with each call.
-// Only use it when the returned value is used directly after the call and there is no concurrency.
-func StaticIntAsShortestBigEndianBytes(value int) []byte {
-	return StaticUint32AsShortestBigEndianBytes(uint32(value))
-}
-
-// StaticUint32AsShortestBigEndianBytes returns an uint32 as the shortest possible byte slice in big endian byte order.
-// It uses a static buffer, so that the content of the buffer changes with each call.
-// Only use it when the returned value is used directly after the call and there is no concurrency.
-func StaticUint32AsShortestBigEndianBytes(value uint32) []byte {
-	return Uint32AsShortestBigEndianBytesIntoBuffer(static32Buffer[:], value)
-}
-
 // IntAsShortestBigEndianBytes returns an int as the shortest possible byte slice in big endian byte order.
 func IntAsShortestBigEndianBytes(value int) []byte {
 	return Uint32AsShortestBigEndianBytes(uint32(value))
@@ -155,16 +139,6 @@ func BigEndianBytesAsUint64(slice []byte) uint64 {
 	return result
 }
 
-// static64Buffer is the static buffer that contains a converted uint32.
-var static64Buffer = [8]byte{}
-
-// StaticUint64AsShortestBigEndianBytes returns an uint64 as the shortest possible byte slice in big endian byte order.
-// It uses a static buffer, so that the content of the buffer changes with each call.
-// Only use it when the returned value is used directly after the call and there is no concurrency.
-func StaticUint64AsShortestBigEndianBytes(value uint64) []byte {
-	return Uint64AsShortestBigEndianBytesIntoBuffer(static64Buffer[:], value)
-}
-
 // Int64AsShortestBigEndianBytes returns an int64 as the shortest possible byte slice in big endian byte order.
 func Int64AsShortestBigEndianBytes(value int64) []byte {
 	return Uint64AsShortestBigEndianBytes(uint64(value))
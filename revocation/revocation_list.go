@@ -0,0 +1,366 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 2.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V2.0.0: Sign with a kept key and an expiry time, so that the list id is stable and old lists expire.
+//
+
+// Package revocation implements a signed list of revoked verification ids.
+//
+// The list is signed with an Ed25519 key that is kept in a key file next to the list.
+// It has a list id that is calculated from the public key in the same way as a verification id.
+// The list id has to be published like a verification id, so that the users of the list can check
+// that it has not been replaced. As the key is kept, the list id does not change when the list is changed.
+//
+// Each signature has an expiry time, so that an old list that does not contain the latest revocations
+// can not be used in place of the current one after it has expired. The list has to be signed again before that.
+package revocation
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha3"
+	"encoding/json"
+	"errors"
+	"filesigner/base32encoding"
+	"filesigner/filehelper"
+	"filesigner/hashsignature"
+	"filesigner/keyid"
+	"filesigner/paddedhasher"
+	"filesigner/stretcher"
+	"filesigner/stringhelper"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+)
+
+// ******** Public types ********
+
+// Revocation is a revoked verification id.
+type Revocation struct {
+	Id     string `json:"id"`
+	Reason string `json:"reason"`
+	Date   string `json:"date"`
+}
+
+// List is the revocation list.
+type List struct {
+	Format      int           `json:"format"`
+	PublicKey   string        `json:"publicKey"`
+	Timestamp   string        `json:"timestamp"`
+	Hostname    string        `json:"hostname"`
+	NotAfter    string        `json:"notAfter"`
+	Revocations []*Revocation `json:"revocations"`
+	Signature   string        `json:"signature"`
+}
+
+// ******** Public variables ********
+
+// ErrModified is returned if the signature of the revocation list is not valid.
+var ErrModified = errors.New(`Revocation list has been modified`)
+
+// ErrExpired is returned if the revocation list has expired.
+var ErrExpired = errors.New(`Revocation list has expired`)
+
+// ErrWrongKey is returned if the key file does not contain the key of the revocation list.
+var ErrWrongKey = errors.New(`Key file does not contain the key of the revocation list`)
+
+// ******** Private constants ********
+
+// listFormat is the format of the revocation list file.
+const listFormat = 1
+
+// listContext is the context of the signature and the list id of a revocation list.
+const listContext = `filesigner revocation list`
+
+// maxListSize is the maximum size of a revocation list file.
+const maxListSize = 16 << 20
+
+// fileMode is the file mode of the revocation list file.
+const fileMode fs.FileMode = 0644
+
+// keySuffix is appended to the path of the revocation list to get the path of its key file.
+const keySuffix = `.key`
+
+// keyFileMode is the file mode of the key file. Only the owner may read the key.
+const keyFileMode fs.FileMode = 0600
+
+// timeStampFormat is the format of the time stamps in the revocation list.
+const timeStampFormat = "2006-01-02 15:04:05 Z07:00"
+
+// ******** Type creation ********
+
+// New creates an empty revocation list.
+func New() *List {
+	return &List{Format: listFormat, Revocations: make([]*Revocation, 0)}
+}
+
+// Load reads the revocation list with the given path and checks its signature.
+// The signature only shows that the list has not been modified since it has been signed.
+// HasId has to be used to check that the list is the one that has been published
+// and CheckExpiry has to be used to check that it is still valid.
+func Load(listPath string) (*List, error) {
+	size, err := filehelper.FileSize(listPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > maxListSize {
+		return nil, errors.New(`Revocation list is too large`)
+	}
+
+	var content []byte
+	content, err = os.ReadFile(listPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &List{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(result)
+	if err != nil {
+		return nil, fmt.Errorf(`Revocation list has an invalid format: %w`, err)
+	}
+
+	if result.Format != listFormat {
+		return nil, fmt.Errorf(`Revocation list has unknown format %d`, result.Format)
+	}
+
+	err = result.checkSignature()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ******** Public functions ********
+
+// KeyPath returns the path of the key file of the revocation list with the given path.
+func KeyPath(listPath string) string {
+	return listPath + keySuffix
+}
+
+// Id returns the list id of the revocation list.
+func (l *List) Id() string {
+	return keyid.KeyId(l.idData()...)
+}
+
+// Words returns the list id of the revocation list in word form.
+func (l *List) Words() string {
+	return keyid.KeyWords(l.idData()...)
+}
+
+// HasId checks if the revocation list has the given list id.
+// The list id may be given in any form that keyid.KeyHashFromId accepts.
+func (l *List) HasId(listId string) (bool, error) {
+	idHash, err := keyid.KeyHashFromId(listId)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(idHash, keyid.KeyHash(l.idData()...)), nil
+}
+
+// Add adds a verification id with a reason to the revocation list.
+// The verification id may be given in any form that keyid.KeyHashFromId accepts.
+func (l *List) Add(verificationId string, reason string) error {
+	trimmedReason := strings.TrimSpace(reason)
+	if len(trimmedReason) == 0 {
+		return errors.New(`Reason must not be empty`)
+	}
+
+	idHash, err := keyid.KeyHashFromId(verificationId)
+	if err != nil {
+		return err
+	}
+
+	if l.Find(idHash) != nil {
+		return errors.New(`Verification id is already revoked`)
+	}
+
+	l.Revocations = append(l.Revocations, &Revocation{
+		Id:     base32encoding.EncodeKeyWithCheck(idHash),
+		Reason: trimmedReason,
+		Date:   time.Now().Format(timeStampFormat),
+	})
+
+	return nil
+}
+
+// Find returns the revocation of the verification id with the given hash or nil, if it is not revoked.
+func (l *List) Find(verificationHash []byte) *Revocation {
+	for _, r := range l.Revocations {
+		idHash, err := keyid.KeyHashFromId(r.Id)
+		if err == nil && bytes.Equal(idHash, verificationHash) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// CheckExpiry checks that the revocation list has not expired at the given time.
+func (l *List) CheckExpiry(t time.Time) error {
+	notAfter, err := time.Parse(timeStampFormat, l.NotAfter)
+	if err != nil {
+		return fmt.Errorf(`Revocation list has an invalid expiry time '%s': %w`, l.NotAfter, err)
+	}
+
+	if t.After(notAfter) {
+		return fmt.Errorf(`%w at %s`, ErrExpired, l.NotAfter)
+	}
+
+	return nil
+}
+
+// Sign signs the revocation list with the key in the key file of the list with the given path.
+// The signature is valid for the given duration.
+// The key file is created with a new key, if it does not exist and the list has not been signed, yet.
+func (l *List) Sign(listPath string, validFor time.Duration) error {
+	hashSigner, err := l.loadSigner(KeyPath(listPath))
+	if err != nil {
+		return err
+	}
+	defer hashSigner.Destroy()
+
+	var publicKeyBytes []byte
+	publicKeyBytes, err = hashSigner.PublicKey()
+	if err != nil {
+		return err
+	}
+
+	publicKey := base32encoding.EncodeToString(publicKeyBytes)
+	if len(l.PublicKey) != 0 && l.PublicKey != publicKey {
+		return ErrWrongKey
+	}
+
+	l.PublicKey = publicKey
+
+	l.Hostname, err = os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	l.Timestamp = now.Format(timeStampFormat)
+	l.NotAfter = now.Add(validFor).Format(timeStampFormat)
+
+	var signature []byte
+	signature, err = hashSigner.SignHash(l.hashValue())
+	if err != nil {
+		return err
+	}
+
+	l.Signature = base32encoding.EncodeToString(signature)
+
+	return nil
+}
+
+// Save writes the revocation list.
+func (l *List) Save(listPath string) error {
+	content, err := json.MarshalIndent(l, ``, `   `)
+	if err != nil {
+		return err
+	}
+
+	return filehelper.WriteFileAtomic(listPath, content, fileMode)
+}
+
+// ******** Private functions ********
+
+// loadSigner creates the signer with the key in the key file.
+// A new key file is only created for a list that has not been signed, yet,
+// as otherwise the list id would change.
+func (l *List) loadSigner(keyPath string) (hashsignature.HashSigner, error) {
+	seed, err := os.ReadFile(keyPath)
+	if errors.Is(err, fs.ErrNotExist) && len(l.PublicKey) == 0 {
+		seed = make([]byte, ed25519.SeedSize)
+		_, _ = rand.Read(seed)
+
+		err = filehelper.WriteFileAtomic(keyPath, seed, keyFileMode)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(`Could not get key of revocation list: %w`, err)
+	}
+
+	return hashsignature.NewEd25519HashSignerFromSeed(seed)
+}
+
+// checkSignature checks the signature of the revocation list with its public key.
+func (l *List) checkSignature() error {
+	publicKeyBytes, err := base32encoding.DecodeFromString(l.PublicKey)
+	if err != nil {
+		return fmt.Errorf(`Could not convert public key to bytes: %w`, err)
+	}
+
+	var signature []byte
+	signature, err = base32encoding.DecodeFromString(l.Signature)
+	if err != nil {
+		return fmt.Errorf(`Could not convert signature to bytes: %w`, err)
+	}
+
+	var hashVerifier hashsignature.HashVerifier
+	hashVerifier, err = hashsignature.NewEd25519HashVerifier(publicKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	if !hashVerifier.VerifyHash(l.hashValue(), signature) {
+		return ErrModified
+	}
+
+	return nil
+}
+
+// hashValue calculates the hash value of the revocation list without its signature.
+func (l *List) hashValue() []byte {
+	signedList := *l
+	signedList.Signature = ``
+
+	hasher := paddedhasher.NewPaddedHasher(hash.Hash(sha3.New512()),
+		stretcher.KeyFromBytes(stringhelper.UnsafeStringBytes(listContext)))
+
+	// Marshalling a structure is deterministic, so the result can be used as the input of the hash.
+	content, _ := json.Marshal(&signedList)
+	_, _ = hasher.Write(content)
+
+	return hasher.Sum(nil)
+}
+
+// idData returns the data that the list id is calculated from.
+// Only the public key is used, so that the list id does not change when the list is signed again.
+func (l *List) idData() [][]byte {
+	return [][]byte{
+		stringhelper.UnsafeStringBytes(listContext),
+		stringhelper.UnsafeStringBytes(l.PublicKey),
+	}
+}
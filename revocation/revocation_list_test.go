@@ -0,0 +1,200 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 2.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V2.0.0: Kept key and expiry.
+//
+
+package revocation
+
+import (
+	"errors"
+	"filesigner/keyid"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ******** Private constants ********
+
+// testValidity is the validity of the test lists.
+const testValidity = time.Hour
+
+// ******** Test functions ********
+
+func TestSignSaveLoad(t *testing.T) {
+	listPath := filepath.Join(t.TempDir(), `revocations.json`)
+
+	l := New()
+	err := l.Add(keyid.KeyWords([]byte(`Test`)), `Build machine compromised`)
+	if err != nil {
+		t.Fatalf(`Could not add id: %v`, err)
+	}
+
+	err = l.Sign(listPath, testValidity)
+	if err != nil {
+		t.Fatalf(`Could not sign list: %v`, err)
+	}
+
+	err = l.Save(listPath)
+	if err != nil {
+		t.Fatalf(`Could not save list: %v`, err)
+	}
+
+	var loaded *List
+	loaded, err = Load(listPath)
+	if err != nil {
+		t.Fatalf(`Could not load list: %v`, err)
+	}
+
+	var hasId bool
+	hasId, err = loaded.HasId(l.Words())
+	if err != nil || !hasId {
+		t.Fatalf(`List id does not match: %v`, err)
+	}
+
+	r := loaded.Find(keyid.KeyHash([]byte(`Test`)))
+	if r == nil || r.Reason != `Build machine compromised` || r.Id != keyid.KeyId([]byte(`Test`)) {
+		t.Fatalf(`Revocation not found: %v`, r)
+	}
+
+	if loaded.Find(keyid.KeyHash([]byte(`Other`))) != nil {
+		t.Fatal(`Revocation found for other id`)
+	}
+
+	var fi os.FileInfo
+	fi, err = os.Stat(KeyPath(listPath))
+	if err != nil {
+		t.Fatalf(`Key file not found: %v`, err)
+	}
+
+	if fi.Mode().Perm() != keyFileMode {
+		t.Fatalf(`Key file has mode %o instead of %o`, fi.Mode().Perm(), keyFileMode)
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	l := New()
+	_ = l.Add(keyid.KeyId([]byte(`Test`)), `Reason`)
+
+	err := l.Add(keyid.KeyWords([]byte(`Test`)), `Other reason`)
+	if err == nil {
+		t.Fatal(`Duplicate id not detected`)
+	}
+}
+
+func TestNewSignatureKeepsId(t *testing.T) {
+	listPath := filepath.Join(t.TempDir(), `revocations.json`)
+
+	l := New()
+	_ = l.Sign(listPath, testValidity)
+	_ = l.Save(listPath)
+	firstId := l.Id()
+
+	loaded, err := Load(listPath)
+	if err != nil {
+		t.Fatalf(`Could not load list: %v`, err)
+	}
+
+	_ = loaded.Add(keyid.KeyId([]byte(`Test`)), `Reason`)
+	err = loaded.Sign(listPath, testValidity)
+	if err != nil {
+		t.Fatalf(`Could not sign list again: %v`, err)
+	}
+
+	hasId, _ := loaded.HasId(firstId)
+	if !hasId {
+		t.Fatal(`List id has changed`)
+	}
+}
+
+func TestMissingKey(t *testing.T) {
+	listPath := filepath.Join(t.TempDir(), `revocations.json`)
+
+	l := New()
+	_ = l.Sign(listPath, testValidity)
+
+	// A signed list must not get a new key, as this would change its id.
+	_ = os.Remove(KeyPath(listPath))
+
+	err := l.Sign(listPath, testValidity)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf(`Missing key not detected: %v`, err)
+	}
+}
+
+func TestWrongKey(t *testing.T) {
+	dirPath := t.TempDir()
+	listPath := filepath.Join(dirPath, `revocations.json`)
+	otherPath := filepath.Join(dirPath, `other.json`)
+
+	l := New()
+	_ = l.Sign(listPath, testValidity)
+
+	// The other list gets a different key.
+	_ = New().Sign(otherPath, testValidity)
+
+	err := l.Sign(otherPath, testValidity)
+	if !errors.Is(err, ErrWrongKey) {
+		t.Fatalf(`Wrong key not detected: %v`, err)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	listPath := filepath.Join(t.TempDir(), `revocations.json`)
+
+	l := New()
+	_ = l.Sign(listPath, testValidity)
+
+	err := l.CheckExpiry(time.Now())
+	if err != nil {
+		t.Fatalf(`Valid list reported as expired: %v`, err)
+	}
+
+	err = l.CheckExpiry(time.Now().Add(2 * testValidity))
+	if !errors.Is(err, ErrExpired) {
+		t.Fatalf(`Expiry not detected: %v`, err)
+	}
+}
+
+func TestModification(t *testing.T) {
+	listPath := filepath.Join(t.TempDir(), `revocations.json`)
+
+	l := New()
+	_ = l.Add(keyid.KeyId([]byte(`Test`)), `Reason`)
+	_ = l.Sign(listPath, testValidity)
+	_ = l.Save(listPath)
+
+	content, _ := os.ReadFile(listPath)
+	modifiedContent := strings.Replace(string(content), keyid.KeyId([]byte(`Test`)), keyid.KeyId([]byte(`Other`)), 1)
+	_ = os.WriteFile(listPath, []byte(modifiedContent), 0600)
+
+	_, err := Load(listPath)
+	if !errors.Is(err, ErrModified) {
+		t.Fatalf(`Modification not detected: %v`, err)
+	}
+}
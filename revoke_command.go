@@ -0,0 +1,101 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.2.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Move revocation check to api package.
+//    2026-10-19: V1.2.0: Sign with the key of the list and sign again without a verification id.
+//
+
+package main
+
+import (
+	"errors"
+	"filesigner/cmdline"
+	"filesigner/keyid"
+	"filesigner/logger"
+	"filesigner/revocation"
+	"io/fs"
+)
+
+// ******** Private functions ********
+
+// doRevoke adds a verification id to a revocation list and signs the list with its key.
+// A list that does not exist is created. Without a verification id the list is only signed again,
+// so that it does not expire.
+func doRevoke(rcl *cmdline.RevokeCommandLine) int {
+	list, err := revocation.Load(rcl.ListPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			logger.PrintErrorf(revokeCmdMsgBase+0, `Could not read revocation list '%s': %v`, rcl.ListPath, err)
+			return rcProcessError
+		}
+
+		if rcl.IsRefresh() {
+			logger.PrintErrorf(revokeCmdMsgBase+11, `Revocation list '%s' does not exist`, rcl.ListPath)
+			return rcProcessError
+		}
+
+		list = revocation.New()
+	}
+
+	if !rcl.IsRefresh() {
+		// A mistyped verification id is reported like in a verification.
+		_, err = keyid.KeyHashFromId(rcl.VerificationId)
+		if err != nil {
+			printInvalidVerificationId(rcl.VerificationId, err)
+			return rcProcessError
+		}
+
+		err = list.Add(rcl.VerificationId, rcl.Reason)
+		if err != nil {
+			logger.PrintErrorf(revokeCmdMsgBase+1, `Could not add verification id '%s' to revocation list: %v`, rcl.VerificationId, err)
+			return rcProcessError
+		}
+	}
+
+	err = list.Sign(rcl.ListPath, rcl.ValidFor)
+	if err != nil {
+		logger.PrintErrorf(revokeCmdMsgBase+10, `Could not sign revocation list: %v`, err)
+		return rcProcessError
+	}
+
+	err = list.Save(rcl.ListPath)
+	if err != nil {
+		logger.PrintErrorf(revokeCmdMsgBase+2, `Could not write revocation list '%s': %v`, rcl.ListPath, err)
+		return rcProcessError
+	}
+
+	if rcl.IsRefresh() {
+		logger.PrintInfof(revokeCmdMsgBase+12, `Revocation list '%s' signed again`, rcl.ListPath)
+	} else {
+		logger.PrintInfof(revokeCmdMsgBase+3, `Verification id '%s' added to revocation list '%s'`, rcl.VerificationId, rcl.ListPath)
+	}
+
+	logger.PrintInfof(revokeCmdMsgBase+13, `Valid until: %s`, list.NotAfter)
+	logger.PrintInfof(revokeCmdMsgBase+4, `List id    : %s`, list.Id())
+	logger.PrintInfof(revokeCmdMsgBase+5, `List words : %s`, list.Words())
+
+	return rcOK
+}
@@ -0,0 +1,144 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.1
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.0.1: Read id list for each verification.
+//
+
+package main
+
+import (
+	"context"
+	"filesigner/api"
+	"filesigner/cmdline"
+	"filesigner/filehelper"
+	"filesigner/logger"
+	"os"
+	"path/filepath"
+)
+
+// ******** Private constants ********
+
+// selfCheckSignaturesFileNamePrefix is the prefix of the name of the published signatures file of the executables.
+// The rest of the name is the program version and the suffix of all signatures files.
+const selfCheckSignaturesFileNamePrefix = `exe-fs_`
+
+// selfCheckSignaturesFileNameSuffix is the suffix of the name of the published signatures file of the executables.
+const selfCheckSignaturesFileNameSuffix = `-signatures.json`
+
+// ******** Private variables ********
+
+// selfCheckIdUrl is the URL of the id list with the verification ids of the published executables.
+// It is set at build time with '-ldflags "-X main.selfCheckIdUrl={url}"'.
+// A verification id itself can not be compiled into the executable, as it is only known after the executable has been signed.
+var selfCheckIdUrl string
+
+// ******** Private functions ********
+
+// doSelfCheck verifies the running executable against its signatures file.
+func doSelfCheck(sccl *cmdline.SelfCheckCommandLine) int {
+	exePath, err := executablePath()
+	if err != nil {
+		logger.PrintErrorf(selfCheckMsgBase+0, `Could not locate the executable: %v`, err)
+		return rcProcessError
+	}
+
+	signaturesPath := sccl.SignaturesPath
+	if len(signaturesPath) == 0 {
+		signaturesPath = filepath.Join(filepath.Dir(exePath),
+			selfCheckSignaturesFileNamePrefix+myVersion+selfCheckSignaturesFileNameSuffix)
+	}
+
+	var resolver api.VerificationIdResolver
+	switch {
+	case len(sccl.VerificationId) != 0:
+		resolver = api.FixedVerificationIds(sccl.VerificationId)
+
+	case len(sccl.IdUrl) != 0:
+		resolver = makeIdListResolver(sccl.IdUrl, false)
+
+	case len(selfCheckIdUrl) != 0:
+		resolver = makeIdListResolver(selfCheckIdUrl, false)
+
+	default:
+		return printUsageError(selfCheckMsgBase+1, `No verification id specified and no id list compiled into this build`)
+	}
+
+	var f *os.File
+	f, err = os.Open(exePath)
+	if err != nil {
+		logger.PrintErrorf(selfCheckMsgBase+2, `Could not open executable '%s': %v`, exePath, err)
+		return rcProcessError
+	}
+	defer filehelper.CloseFile(f)
+
+	logger.PrintInfof(selfCheckMsgBase+3, `Checking executable '%s' against signatures file '%s'`, exePath, signaturesPath)
+
+	// The executable is verified as data, so that only its own signature is checked.
+	var report *api.Report
+	report, err = api.Verify(context.Background(), &api.VerifyOptions{
+		SignaturesFileName:     signaturesPath,
+		DataName:               filepath.Base(exePath),
+		DataReader:             f,
+		ResolveVerificationIds: resolver,
+	})
+	if err != nil {
+		rc := printVerifyError(err)
+		printNotGenuine(exePath)
+		return rc
+	}
+
+	printMetaData(&report.SignatureInfo)
+
+	for _, warning := range report.Warnings {
+		logger.PrintWarning(warningMsgNumber(warning), warning.Error())
+	}
+
+	printFileResults(report.Files)
+
+	if report.ErrorCount() != 0 || report.SuccessCount() == 0 {
+		printNotGenuine(exePath)
+		return rcProcessError
+	}
+
+	logger.PrintInfof(selfCheckMsgBase+4, `Executable '%s' is genuine`, exePath)
+
+	return rcOK
+}
+
+// executablePath returns the path of the running executable with all symbolic links resolved.
+func executablePath() (string, error) {
+	result, err := os.Executable()
+	if err != nil {
+		return ``, err
+	}
+
+	return filepath.EvalSymlinks(result)
+}
+
+// printNotGenuine prints that the executable is not genuine.
+func printNotGenuine(exePath string) {
+	logger.PrintErrorf(selfCheckMsgBase+5, `Executable '%s' is NOT genuine`, exePath)
+}
@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,7 +20,7 @@
 //
 // Author: Frank Schwab
 //
-// Version: 2.0.0
+// Version: 4.0.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
@@ -28,113 +28,78 @@
 //    2024-03-04: V1.2.0: Use public key bytes, not id.
 //    2025-03-01: V1.3.0: Add message base.
 //    2025-05-25: V2.0.0: Add "beQuiet" parameter.
+//    2026-10-19: V2.1.0: Sign entries of archives.
+//    2026-10-19: V3.0.0: Write signed files and signatures into archives, use command line object.
+//    2026-10-19: V3.1.0: Add Merkle tree hash mode.
+//    2026-10-19: V3.2.0: Sign data from stdin.
+//    2026-10-19: V3.3.0: Print verification id in word form.
+//    2026-10-19: V3.4.0: Append signatures to signature log.
+//    2026-10-19: V3.5.0: Add expiry of signatures.
+//    2026-10-19: V3.6.0: Sign metadata of files.
+//    2026-10-19: V3.7.0: Record symbolic links.
+//    2026-10-19: V4.0.0: Use api package.
 //
 
 package main
 
 import (
-	"filesigner/base32encoding"
-	"filesigner/filehasher"
-	"filesigner/filesignature"
-	"filesigner/hashsignature"
+	"context"
+	"errors"
+	"filesigner/api"
+	"filesigner/cmdline"
 	"filesigner/logger"
-	"filesigner/signaturefile"
-	"filesigner/signaturehandler"
-	"filesigner/stretcher"
-	"filesigner/stringhelper"
 	"filesigner/texthelper"
 	"fmt"
 	"os"
-	"time"
 )
 
-// ******** Private constants ********
-
-// timeStampFormat RFC3339 format for signatures file time stamp
-const timeStampFormat = "2006-01-02 15:04:05 Z07:00"
-
 // ******** Private functions ********
 
 // doSigning signs all files with the given context id.
-func doSigning(
-	signaturesFileName string,
-	signatureType signaturehandler.SignatureType,
-	contextId string,
-	beQuiet bool,
-	filePaths []string,
-) int {
-	var err error
-
-	signatureData := &signaturehandler.SignatureData{
-		Format:        signaturehandler.SignatureFormatV1,
-		Timestamp:     time.Now().Format(timeStampFormat),
-		SignatureType: signatureType,
-		ContextId:     contextId,
-	}
-
-	signatureData.Hostname, err = os.Hostname()
-	if err != nil {
-		logger.PrintErrorf(signCmdMsgBase+0, `Could not get host name: %v`, err)
-		return rcProcessError
-	}
-
-	contextKey := stretcher.KeyFromBytes(stringhelper.UnsafeStringBytes(contextId))
-	resultList := filehasher.FileHashes(filePaths, contextKey)
-
-	if existHashErrors(resultList) {
-		return rcProcessError
-	}
-
-	var hashSigner hashsignature.HashSigner
-	if signatureType == signaturehandler.SignatureTypeEd25519 {
-		hashSigner, err = hashsignature.NewEd25519HashSigner()
-	} else {
-		hashSigner, err = hashsignature.NewEcDsaP521HashSigner()
-	}
-	if err != nil {
-		logger.PrintErrorf(signCmdMsgBase+1, `Could not create hash-signer: %v`, err)
-		return rcProcessError
+// If an archive path is given, the entries of the archive are signed instead of the files.
+// If a name for data from stdin is given, the data from stdin are signed under this name instead of the files.
+// If an into-archive path is given, the files and the signatures file are written into that archive.
+func doSigning(contextId string, scl *cmdline.SignCommandLine) int {
+	options := &api.SignOptions{
+		ContextId:          contextId,
+		SignatureType:      scl.SignatureType,
+		FilePaths:          scl.FileList,
+		ArchivePath:        scl.ArchivePath,
+		IntoArchivePath:    scl.IntoArchivePath,
+		SignaturesFileName: scl.SignaturesFileName,
+		ChunkSize:          scl.ChunkSize,
+		NotAfter:           scl.NotAfter,
+		WithMetadata:       scl.WithMetadata,
+		SymlinkMode:        scl.SymlinkMode,
+		LogPath:            scl.LogPath,
 	}
-	defer hashSigner.Destroy()
 
-	var publicKeyBytes []byte
-	publicKeyBytes, err = hashSigner.PublicKey()
-	if err != nil {
-		logger.PrintErrorf(signCmdMsgBase+2, `Could not get public key bytes: %v`, err)
-		return rcProcessError
-	}
-	signatureData.PublicKey = base32encoding.EncodeToString(publicKeyBytes)
-
-	var successList []string
-	signatureData.FileSignatures, successList, err = filesignature.SignFileHashes(hashSigner, resultList)
-	if err != nil {
-		logger.PrintErrorf(signCmdMsgBase+3, `Could not sign file hashes: %v`, err)
-		return rcProcessError
-	}
-
-	err = signatureData.Sign(hashSigner, contextKey)
-	if err != nil {
-		logger.PrintErrorf(signCmdMsgBase+4, `Could not sign signatures file data: %v`, err)
-		return rcProcessError
+	if len(scl.StdinDataName) != 0 {
+		options.DataName = scl.StdinDataName
+		options.DataReader = os.Stdin
 	}
 
-	err = signaturefile.WriteJson(signaturesFileName, signatureData)
+	result, err := api.Sign(context.Background(), options)
 	if err != nil {
-		logger.PrintErrorf(signCmdMsgBase+5, `Error writing signatures file '%s': %v`, signaturesFileName, err)
-		return rcProcessError
+		return printSignError(err)
 	}
 
-	printMetaData(signatureData, publicKeyBytes)
+	printMetaData(&result.SignatureInfo)
 
-	if beQuiet {
-		fmt.Println(makeVerificationId(signatureData, publicKeyBytes))
+	if scl.BeQuiet {
+		if scl.PrintWords {
+			fmt.Println(result.VerificationWords)
+		} else {
+			fmt.Println(result.VerificationId)
+		}
 	} else {
-		logger.PrintInfof(signCmdMsgBase+6, `Verification id    : %s`, makeVerificationId(signatureData, publicKeyBytes))
+		logger.PrintInfof(signCmdMsgBase+6, `Verification id    : %s`, result.VerificationId)
+		logger.PrintInfof(signCmdMsgBase+13, `Verification words : %s`, result.VerificationWords)
 	}
 
-	successCount := len(successList)
+	successCount := len(result.FilePaths)
 	if successCount > 0 {
-		printSuccessList(`Signing`, successList)
+		printSuccessList(`Signing`, result.FilePaths)
 	}
 
 	successEnding := texthelper.GetCountEnding(successCount)
@@ -142,9 +107,27 @@ func doSigning(
 	logger.PrintInfof(signCmdMsgBase+7,
 		`Signature%s for %d file%s successfully created and written to '%s'`,
 		successEnding,
-		len(successList),
+		successCount,
 		successEnding,
-		signaturesFileName)
+		result.SignaturesPath)
 
 	return rcOK
 }
+
+// printSignError prints an error that occurred during signing and returns the return code.
+func printSignError(err error) int {
+	var hashErr *api.HashError
+	switch {
+	case errors.As(err, &hashErr):
+		printFileErrors(hashErr.Errors)
+
+	case errors.Is(err, api.ErrNoFiles):
+		logger.PrintWarning(signCmdMsgBase+9, err.Error())
+		return rcProcessWarning
+
+	default:
+		logger.PrintError(signCmdMsgBase+0, err.Error())
+	}
+
+	return rcProcessError
+}
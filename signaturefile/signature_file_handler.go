@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,10 +20,16 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.6.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Read and write signature data as bytes and from archives.
+//    2026-10-19: V1.2.0: Check extension fields of format 2.
+//    2026-10-19: V1.3.0: Check expiry field.
+//    2026-10-19: V1.4.0: Check file metadata field.
+//    2026-10-19: V1.5.0: Check symbolic link fields.
+//    2026-10-19: V1.6.0: Read signature data from a file system.
 //
 
 package signaturefile
@@ -32,10 +38,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"filesigner/archive"
+	"filesigner/filehasher"
 	"filesigner/filehelper"
 	"filesigner/signaturehandler"
 	"fmt"
+	"io/fs"
 	"os"
+	"time"
 )
 
 // ******** Private constants ********
@@ -47,9 +57,9 @@ const maxFileSize = 50_000_000
 
 // WriteJson writes the signature data to the specified file in JSON format.
 func WriteJson(filePath string, signatureData *signaturehandler.SignatureData) error {
-	jsonOutput, err := json.MarshalIndent(signatureData, "", "   ")
+	jsonOutput, err := JsonBytes(signatureData)
 	if err != nil {
-		return fmt.Errorf(`Could not convert data to JSON format: %w`, err)
+		return err
 	}
 
 	err = os.WriteFile(filePath, jsonOutput, 0600)
@@ -60,6 +70,16 @@ func WriteJson(filePath string, signatureData *signaturehandler.SignatureData) e
 	return nil
 }
 
+// JsonBytes returns the signature data in JSON format.
+func JsonBytes(signatureData *signaturehandler.SignatureData) ([]byte, error) {
+	jsonOutput, err := json.MarshalIndent(signatureData, "", "   ")
+	if err != nil {
+		return nil, fmt.Errorf(`Could not convert data to JSON format: %w`, err)
+	}
+
+	return jsonOutput, nil
+}
+
 // ReadJson reads a signatures file in JSON format and returns the signature data.
 func ReadJson(filePath string) (*signaturehandler.SignatureData, error) {
 	err := checkFileSize(filePath)
@@ -67,8 +87,50 @@ func ReadJson(filePath string) (*signaturehandler.SignatureData, error) {
 		return nil, err
 	}
 
-	var result *signaturehandler.SignatureData
-	result, err = getSignatureData(filePath)
+	var fileContent []byte
+	fileContent, err = os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJson(fileContent)
+}
+
+// ReadJsonFS reads a signatures file in JSON format from a file system and returns the signature data.
+func ReadJsonFS(fsys fs.FS, filePath string) (*signaturehandler.SignatureData, error) {
+	fi, err := fs.Stat(fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkSize(fi.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	var fileContent []byte
+	fileContent, err = fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJson(fileContent)
+}
+
+// ReadJsonFromArchive reads a signatures file in JSON format from an archive entry and returns the signature data.
+// If the archive does not contain the entry, the returned error is archive.ErrEntryNotFound.
+func ReadJsonFromArchive(archivePath string, entryPath string) (*signaturehandler.SignatureData, error) {
+	entryContent, err := archive.ReadEntry(archivePath, entryPath, maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJson(entryContent)
+}
+
+// ParseJson parses signature data in JSON format and checks them for formal correctness.
+func ParseJson(content []byte) (*signaturehandler.SignatureData, error) {
+	result, err := getSignatureData(content)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +152,11 @@ func checkFileSize(filePath string) error {
 		return err
 	}
 
+	return checkSize(fileSize)
+}
+
+// checkSize checks if a size of a signatures file is within the allowed boundaries.
+func checkSize(fileSize int64) error {
 	if fileSize > maxFileSize {
 		return errors.New(`Signatures file is too large`)
 	}
@@ -97,18 +164,13 @@ func checkFileSize(filePath string) error {
 	return nil
 }
 
-// getSignatureData reads the signature data from a file and returns the data in a SignatureData structure.
-func getSignatureData(filePath string) (*signaturehandler.SignatureData, error) {
-	fileContent, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, err
-	}
-
+// getSignatureData converts the JSON content into a SignatureData structure.
+func getSignatureData(content []byte) (*signaturehandler.SignatureData, error) {
 	result := &signaturehandler.SignatureData{
 		Format:        signaturehandler.SignatureFormatInvalid,
 		SignatureType: signaturehandler.SignatureTypeInvalid,
 	}
-	err = strictJsonUnmarshal(fileContent, result)
+	err := strictJsonUnmarshal(content, result)
 
 	return result, err
 }
@@ -136,6 +198,105 @@ func checkSignatureForm(signatureData *signaturehandler.SignatureData) error {
 		return fmt.Errorf(`Invalid signature type: %d`, signatureData.SignatureType)
 	}
 
+	return checkExtensionFields(signatureData)
+}
+
+// checkExtensionFields checks if the extension fields are valid and match the format.
+func checkExtensionFields(signatureData *signaturehandler.SignatureData) error {
+	hasExtensions := signatureData.HasExtensions()
+	if signatureData.Format == signaturehandler.SignatureFormatV1 {
+		if hasExtensions {
+			return errors.New(`Signatures file with format 1 must not contain extension fields`)
+		}
+
+		return nil
+	}
+
+	if !hasExtensions {
+		return fmt.Errorf(`Signatures file with format %d must contain extension fields`, signatureData.Format)
+	}
+
+	switch signatureData.HashMode {
+	case signaturehandler.HashModeStream:
+		if signatureData.ChunkSize != 0 {
+			return errors.New(`Field 'chunkSize' is not allowed without Merkle tree hash mode`)
+		}
+
+	case signaturehandler.HashModeMerkle:
+		err := filehasher.CheckChunkSize(signatureData.ChunkSize)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf(`Invalid hash mode: %d`, signatureData.HashMode)
+	}
+
+	if len(signatureData.NotAfter) != 0 {
+		_, err := time.Parse(signaturehandler.TimestampFormat, signatureData.NotAfter)
+		if err != nil {
+			return fmt.Errorf(`Invalid expiry time: '%s'`, signatureData.NotAfter)
+		}
+	}
+
+	err := checkFileMetadata(signatureData)
+	if err != nil {
+		return err
+	}
+
+	return checkSymlinks(signatureData)
+}
+
+// checkFileMetadata checks that the file metadata are valid and that there are metadata for every signed file.
+func checkFileMetadata(signatureData *signaturehandler.SignatureData) error {
+	if len(signatureData.FileMetadata) == 0 {
+		return nil
+	}
+
+	if len(signatureData.FileMetadata) != len(signatureData.FileSignatures) {
+		return errors.New(`Field 'fileMetadata' does not contain the same files as field 'fileSignatures'`)
+	}
+
+	for filePath, metadata := range signatureData.FileMetadata {
+		_, isSigned := signatureData.FileSignatures[filePath]
+		if !isSigned {
+			return fmt.Errorf(`Field 'fileMetadata' contains file '%s' that is not signed`, filePath)
+		}
+
+		if metadata == nil {
+			return fmt.Errorf(`Metadata of file '%s' are missing`, filePath)
+		}
+
+		err := metadata.Check()
+		if err != nil {
+			return fmt.Errorf(`Metadata of file '%s' are invalid: %w`, filePath, err)
+		}
+	}
+
+	return nil
+}
+
+// checkSymlinks checks that the symbolic link mode is valid and that the recorded links are signed.
+func checkSymlinks(signatureData *signaturehandler.SignatureData) error {
+	if signatureData.SymlinkMode > signaturehandler.SymlinkModeMax {
+		return fmt.Errorf(`Invalid symbolic link mode: %d`, signatureData.SymlinkMode)
+	}
+
+	if len(signatureData.Symlinks) != 0 && signatureData.SymlinkMode != signaturehandler.SymlinkModeRecord {
+		return errors.New(`Field 'symlinks' is only allowed with symbolic link mode 'record'`)
+	}
+
+	for filePath, target := range signatureData.Symlinks {
+		_, isSigned := signatureData.FileSignatures[filePath]
+		if !isSigned {
+			return fmt.Errorf(`Field 'symlinks' contains file '%s' that is not signed`, filePath)
+		}
+
+		if len(target) == 0 {
+			return fmt.Errorf(`Target of symbolic link '%s' is missing`, filePath)
+		}
+	}
+
 	return nil
 }
 
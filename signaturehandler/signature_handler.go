@@ -20,7 +20,7 @@
 //
 // Author: Frank Schwab
 //
-// Version: 3.1.0
+// Version: 3.5.1
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
@@ -28,6 +28,11 @@
 //    2024-02-25: V2.0.0: Rename "Ed25519" to "Ed25519Ph".
 //    2025-05-22: V3.0.0: Return signature of all data in Sign call.
 //    2026-08-20: V3.1.0: Use "crypto/sha3".
+//    2026-10-19: V3.2.0: Add format 2 with extension fields and hash mode.
+//    2026-10-19: V3.3.0: Add expiry extension field.
+//    2026-10-19: V3.4.0: Add file metadata extension field.
+//    2026-10-19: V3.5.0: Add symbolic link extension fields.
+//    2026-10-19: V3.5.1: Do not use static buffers for hashing.
 //
 
 package signaturehandler
@@ -35,6 +40,7 @@ package signaturehandler
 import (
 	"crypto/sha3"
 	"filesigner/base32encoding"
+	"filesigner/filemetadata"
 	"filesigner/hashsignature"
 	"filesigner/maphelper"
 	"filesigner/numberhelper"
@@ -52,24 +58,41 @@ type signatureFormat byte
 // SignatureType contains the code for the signature algorithm.
 type SignatureType byte
 
+// HashMode contains the code for the method that is used to hash the file contents.
+type HashMode byte
+
+// SymlinkMode contains the code for the treatment of symbolic links.
+type SymlinkMode byte
+
 // SignatureData contains all the data that comprise a filesigner signature.
+// The fields after SignatureType are extension fields that are only present in format 2.
 type SignatureData struct {
-	Format         signatureFormat   `json:"format"`
-	ContextId      string            `json:"contextId"`
-	PublicKey      string            `json:"publicKey"`
-	Timestamp      string            `json:"timestamp"`
-	Hostname       string            `json:"hostname"`
-	SignatureType  SignatureType     `json:"signatureType"`
-	FileSignatures map[string]string `json:"fileSignatures"`
-	DataSignature  string            `json:"dataSignature"`
+	Format         signatureFormat                   `json:"format"`
+	ContextId      string                            `json:"contextId"`
+	PublicKey      string                            `json:"publicKey"`
+	Timestamp      string                            `json:"timestamp"`
+	Hostname       string                            `json:"hostname"`
+	SignatureType  SignatureType                     `json:"signatureType"`
+	HashMode       HashMode                          `json:"hashMode,omitempty"`
+	ChunkSize      int64                             `json:"chunkSize,omitempty"`
+	NotAfter       string                            `json:"notAfter,omitempty"`
+	FileMetadata   map[string]*filemetadata.Metadata `json:"fileMetadata,omitempty"`
+	SymlinkMode    SymlinkMode                       `json:"symlinkMode,omitempty"`
+	Symlinks       map[string]string                 `json:"symlinks,omitempty"`
+	FileSignatures map[string]string                 `json:"fileSignatures"`
+	DataSignature  string                            `json:"dataSignature"`
 }
 
 // ******** Public constants ********
 
+// TimestampFormat is the format of the time stamps in the signature data.
+const TimestampFormat = "2006-01-02 15:04:05 Z07:00"
+
 // These are the possible values for signatureFormat.
 const (
 	SignatureFormatInvalid signatureFormat = iota
 	SignatureFormatV1
+	SignatureFormatV2
 	SignatureFormatMax = iota - 1
 )
 
@@ -81,6 +104,26 @@ const (
 	SignatureTypeMax = iota - 1
 )
 
+// These are the possible values for HashMode.
+// HashModeStream is the default and hashes the content of a file as one stream.
+// HashModeMerkle hashes chunks of a file and combines them in a Merkle tree.
+const (
+	HashModeStream HashMode = iota
+	HashModeMerkle
+	HashModeMax = iota - 1
+)
+
+// These are the possible values for SymlinkMode.
+// SymlinkModeFollow is the default and hashes the content that a symbolic link points to.
+// SymlinkModeRecord hashes the target of a symbolic link.
+// SymlinkModeReject does not allow symbolic links.
+const (
+	SymlinkModeFollow SymlinkMode = iota
+	SymlinkModeRecord
+	SymlinkModeReject
+	SymlinkModeMax = iota - 1
+)
+
 // ******** Public type functions ********
 
 // Sign adds the data signature to a SignatureData.
@@ -102,8 +145,91 @@ func (sd *SignatureData) Verify(hashVerifier hashsignature.HashVerifier, context
 	return hashVerifier.VerifyHash(hashValue, dataSignature), nil
 }
 
+// HasExtensions reports whether any extension field is set.
+func (sd *SignatureData) HasExtensions() bool {
+	return len(extensionFields(sd)) != 0
+}
+
+// SetFormat sets the lowest format that can contain the signature data.
+func (sd *SignatureData) SetFormat() {
+	if sd.HasExtensions() {
+		sd.Format = SignatureFormatV2
+	} else {
+		sd.Format = SignatureFormatV1
+	}
+}
+
+// ******** Private types ********
+
+// extensionField is the name and the binary value of an extension field.
+type extensionField struct {
+	name  string
+	value []byte
+}
+
 // ******** Private functions ********
 
+// extensionFields returns the extension fields that are set, in a fixed order.
+func extensionFields(sd *SignatureData) []extensionField {
+	var result []extensionField
+
+	if sd.HashMode != HashModeStream {
+		result = append(result, extensionField{`hashMode`, []byte{byte(sd.HashMode)}})
+	}
+
+	if sd.ChunkSize != 0 {
+		result = append(result, extensionField{`chunkSize`, numberhelper.Int64AsShortestBigEndianBytes(sd.ChunkSize)})
+	}
+
+	if len(sd.NotAfter) != 0 {
+		result = append(result, extensionField{`notAfter`, stringhelper.UnsafeStringBytes(sd.NotAfter)})
+	}
+
+	if len(sd.FileMetadata) != 0 {
+		result = append(result, extensionField{`fileMetadata`, fileMetadataBytes(sd.FileMetadata)})
+	}
+
+	if sd.SymlinkMode != SymlinkModeFollow {
+		result = append(result, extensionField{`symlinkMode`, []byte{byte(sd.SymlinkMode)}})
+	}
+
+	if len(sd.Symlinks) != 0 {
+		result = append(result, extensionField{`symlinks`, symlinksBytes(sd.Symlinks)})
+	}
+
+	return result
+}
+
+// fileMetadataBytes returns the byte representation of the file metadata.
+// It consists of the file names and the byte representations of their metadata, each followed by a 0 byte.
+// The file names are sorted, so the result is the same for the same metadata.
+func fileMetadataBytes(fileMetadata map[string]*filemetadata.Metadata) []byte {
+	var result []byte
+	for _, fileName := range maphelper.SortedKeys(fileMetadata) {
+		result = append(result, fileName...)
+		result = append(result, 0)
+		result = append(result, fileMetadata[fileName].Bytes()...)
+		result = append(result, 0)
+	}
+
+	return result
+}
+
+// symlinksBytes returns the byte representation of the symbolic links.
+// It consists of the file names and the link targets, each followed by a 0 byte.
+// The file names are sorted, so the result is the same for the same links.
+func symlinksBytes(symlinks map[string]string) []byte {
+	var result []byte
+	for _, fileName := range maphelper.SortedKeys(symlinks) {
+		result = append(result, fileName...)
+		result = append(result, 0)
+		result = append(result, symlinks[fileName]...)
+		result = append(result, 0)
+	}
+
+	return result
+}
+
 // hashValueOfSignatureData calculates the hash value of a SignatureData.
 func hashValueOfSignatureData(signatureData *SignatureData, contextKey []byte) []byte {
 	hasher := paddedhasher.NewPaddedHasher(hash.
@@ -124,6 +250,11 @@ func hashValueOfSignatureData(signatureData *SignatureData, contextKey []byte) [
 	oneByteSlice[0] = byte(signatureData.SignatureType)
 	position = hashBytesWithPosition(hasher, position, oneByteSlice)
 
+	// Format 1 has no extension fields, so its hash value is the same as before they were introduced.
+	if signatureData.Format >= SignatureFormatV2 {
+		position = hashExtensionFields(hasher, position, signatureData)
+	}
+
 	sortedFileNames := maphelper.SortedKeys(signatureData.FileSignatures)
 	for _, fileName := range sortedFileNames {
 		position = hashStringWithPosition(hasher, position, fileName)
@@ -133,6 +264,20 @@ func hashValueOfSignatureData(signatureData *SignatureData, contextKey []byte) [
 	return hasher.Sum(nil)
 }
 
+// hashExtensionFields hashes the number of extension fields and their names and values.
+// The number makes it impossible to mistake an extension field for a file signature.
+func hashExtensionFields(hasher hash.Hash, position uint32, signatureData *SignatureData) uint32 {
+	fields := extensionFields(signatureData)
+
+	position = hashBytesWithPosition(hasher, position, numberhelper.IntAsShortestBigEndianBytes(len(fields)))
+	for _, field := range fields {
+		position = hashStringWithPosition(hasher, position, field.name)
+		position = hashBytesWithPosition(hasher, position, field.value)
+	}
+
+	return position
+}
+
 // hashStringWithPosition hashes a string with the given position.
 func hashStringWithPosition(hasher hash.Hash, position uint32, text string) uint32 {
 	return hashBytesWithPosition(hasher, position, stringhelper.UnsafeStringBytes(text))
@@ -142,15 +287,13 @@ func hashStringWithPosition(hasher hash.Hash, position uint32, text string) uint
 func hashBytesWithPosition(hasher hash.Hash, position uint32, b []byte) uint32 {
 	position = hashPosition(hasher, position)
 	hasher.Write(b)
-	hasher.Write(numberhelper.StaticIntAsShortestBigEndianBytes(len(b)))
+	hasher.Write(numberhelper.IntAsShortestBigEndianBytes(len(b)))
 	return position
 }
 
 // hashPosition hashes the position.
 func hashPosition(hasher hash.Hash, position uint32) uint32 {
 	position++
-	// It is safe to use StaticUint32AsShortestBigEndianBytes here, as the returned byte slice
-	// is immediately used and there is no concurrency.
-	hasher.Write(numberhelper.StaticUint32AsShortestBigEndianBytes(position))
+	hasher.Write(numberhelper.Uint32AsShortestBigEndianBytes(position))
 	return position
 }
@@ -0,0 +1,64 @@
+//go:build windows
+
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package signaturelog
+
+import (
+	"golang.org/x/sys/windows"
+	"os"
+)
+
+// ******** Private constants ********
+
+// lockOffset is the offset of the locked byte.
+// Locks on Windows are mandatory, so a byte far behind the end of the log is locked,
+// which does not prevent others from reading the log.
+const lockOffset = 0x7fffffff
+
+// ******** Private functions ********
+
+// lockFile waits until it gets an exclusive lock on the file.
+func lockFile(f *os.File) error {
+	return windows.LockFileEx(windows.Handle(f.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK,
+		0,
+		1,
+		0,
+		&windows.Overlapped{Offset: lockOffset, OffsetHigh: lockOffset})
+}
+
+// unlockFile releases the lock on the file.
+func unlockFile(f *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(f.Fd()),
+		0,
+		1,
+		0,
+		&windows.Overlapped{Offset: lockOffset, OffsetHigh: lockOffset})
+}
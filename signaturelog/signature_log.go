@@ -0,0 +1,325 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Lock the log file while appending.
+//
+
+// Package signaturelog implements an append-only log of issued signatures.
+//
+// The log is a text file with one json entry per line. Each entry contains the hash
+// of the previous entry and its own hash, so the entries form a hash chain.
+// Modifying, deleting or inserting an entry breaks the chain, unless all following
+// entries are recalculated, as well. So the log should be copied regularly to a place
+// where it can not be modified by the signing machines.
+package signaturelog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha3"
+	"encoding/json"
+	"errors"
+	"filesigner/base32encoding"
+	"filesigner/filehelper"
+	"filesigner/keyid"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ******** Public types ********
+
+// Entry is an entry of the signature log.
+type Entry struct {
+	Sequence        uint64 `json:"seq"`
+	Timestamp       string `json:"timestamp"`
+	VerificationId  string `json:"verificationId"`
+	ContextId       string `json:"contextId"`
+	Hostname        string `json:"hostname"`
+	SignatureDigest string `json:"signatureDigest"`
+	Previous        string `json:"previous"`
+	Hash            string `json:"hash"`
+}
+
+// Log is the signature log.
+type Log struct {
+	path    string
+	entries []*Entry
+}
+
+// ChainError is returned if an entry of the log does not fit into the hash chain.
+type ChainError struct {
+	// Line is the number of the line with the invalid entry, starting at 1.
+	Line int
+	// Reason describes what is wrong with the entry.
+	Reason string
+}
+
+// Error returns the error text.
+func (e *ChainError) Error() string {
+	return fmt.Sprintf(`Signature log is broken in line %d: %s`, e.Line, e.Reason)
+}
+
+// ******** Private constants ********
+
+// maxLineSize is the maximum size of a line in the log.
+const maxLineSize = 64 * 1024
+
+// dirMode is the file mode of a created log directory.
+const dirMode fs.FileMode = 0755
+
+// fileMode is the file mode of the log file.
+const fileMode fs.FileMode = 0644
+
+// ******** Type creation ********
+
+// NewEntry creates a log entry for a signatures file.
+// The data signature is not stored, only its digest.
+func NewEntry(verificationId string,
+	contextId string,
+	timestamp string,
+	hostname string,
+	dataSignature string) (*Entry, error) {
+	signatureBytes, err := base32encoding.DecodeFromString(dataSignature)
+	if err != nil {
+		return nil, fmt.Errorf(`Data signature is invalid: %w`, err)
+	}
+
+	digest := sha3.Sum256(signatureBytes)
+
+	return &Entry{
+		Timestamp:       timestamp,
+		VerificationId:  verificationId,
+		ContextId:       contextId,
+		Hostname:        hostname,
+		SignatureDigest: base32encoding.EncodeToString(digest[:]),
+	}, nil
+}
+
+// Load reads the signature log with the given path and checks its hash chain.
+// If the log does not exist, an empty log is returned.
+func Load(logPath string) (*Log, error) {
+	result := &Log{path: logPath}
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return result, nil
+		}
+
+		return nil, err
+	}
+	defer filehelper.CloseFile(f)
+
+	result.entries, err = readEntries(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ******** Public functions ********
+
+// Path returns the path of the signature log.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Entries returns the entries of the signature log.
+func (l *Log) Entries() []*Entry {
+	return l.entries
+}
+
+// Find returns the entries with the given verification id.
+// The verification id may be given in any form that keyid.KeyHashFromId accepts.
+func (l *Log) Find(verificationId string) ([]*Entry, error) {
+	idHash, err := keyid.KeyHashFromId(verificationId)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Entry
+	for _, e := range l.entries {
+		var entryHash []byte
+		entryHash, err = keyid.KeyHashFromId(e.VerificationId)
+		if err == nil && bytes.Equal(entryHash, idHash) {
+			result = append(result, e)
+		}
+	}
+
+	return result, nil
+}
+
+// Append adds an entry to the end of the signature log and writes it to the log file.
+// The sequence number and the hashes of the entry are set by this function.
+// The directory of the log is created, if it does not exist.
+//
+// The log file is locked while the entry is appended. As another process may have appended
+// entries since the log has been loaded, the entries are read again while the lock is held.
+func (l *Log) Append(entry *Entry) error {
+	err := os.MkdirAll(filepath.Dir(l.path), dirMode)
+	if err != nil {
+		return err
+	}
+
+	var f *os.File
+	f, err = os.OpenFile(l.path, os.O_RDWR|os.O_APPEND|os.O_CREATE, fileMode)
+	if err != nil {
+		return err
+	}
+
+	err = l.appendLocked(f, entry)
+
+	return errors.Join(err, f.Close())
+}
+
+// ******** Private functions ********
+
+// appendLocked locks the opened log file, reads its entries and appends the entry.
+func (l *Log) appendLocked(f *os.File, entry *Entry) error {
+	err := lockFile(f)
+	if err != nil {
+		return fmt.Errorf(`Could not lock signature log: %w`, err)
+	}
+	defer func() { _ = unlockFile(f) }()
+
+	var entries []*Entry
+	entries, err = readEntries(f)
+	if err != nil {
+		return err
+	}
+
+	entry.Sequence = uint64(len(entries) + 1)
+	entry.Previous = lastHash(entries)
+	entry.Hash = calculateHash(entry)
+
+	var line []byte
+	line, err = json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	_, err = f.Write(line)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err != nil {
+		return err
+	}
+
+	l.entries = append(entries, entry)
+
+	return nil
+}
+
+// readEntries reads the entries of a log and checks their hash chain.
+func readEntries(r io.Reader) ([]*Entry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineSize)
+
+	var result []*Entry
+	previous := ``
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		entry, err := parseEntry(scanner.Bytes())
+		if err != nil {
+			return nil, &ChainError{Line: lineNumber, Reason: err.Error()}
+		}
+
+		err = checkEntry(entry, uint64(len(result)+1), previous)
+		if err != nil {
+			return nil, &ChainError{Line: lineNumber, Reason: err.Error()}
+		}
+
+		result = append(result, entry)
+		previous = entry.Hash
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// lastHash returns the hash of the last entry or an empty string, if there are no entries.
+func lastHash(entries []*Entry) string {
+	if len(entries) == 0 {
+		return ``
+	}
+
+	return entries[len(entries)-1].Hash
+}
+
+// parseEntry parses a line of the log.
+func parseEntry(line []byte) (*Entry, error) {
+	result := &Entry{}
+
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(result)
+	if err != nil {
+		return nil, fmt.Errorf(`Invalid format: %v`, err)
+	}
+
+	return result, nil
+}
+
+// checkEntry checks that an entry has the expected sequence number, the hash of the previous entry and a correct hash.
+func checkEntry(entry *Entry, sequence uint64, previous string) error {
+	if entry.Sequence != sequence {
+		return fmt.Errorf(`Sequence number is %d instead of %d`, entry.Sequence, sequence)
+	}
+
+	if entry.Previous != previous {
+		return errors.New(`Hash of previous entry does not match`)
+	}
+
+	if entry.Hash != calculateHash(entry) {
+		return errors.New(`Hash of entry does not match`)
+	}
+
+	return nil
+}
+
+// calculateHash calculates the hash of an entry without its hash field.
+func calculateHash(entry *Entry) string {
+	hashedEntry := *entry
+	hashedEntry.Hash = ``
+
+	// Marshalling a structure is deterministic, so the result can be used as the input of the hash.
+	content, _ := json.Marshal(&hashedEntry)
+	hashValue := sha3.Sum256(content)
+
+	return base32encoding.EncodeToString(hashValue[:])
+}
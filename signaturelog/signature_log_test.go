@@ -0,0 +1,198 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Concurrent appends.
+//
+
+package signaturelog
+
+import (
+	"errors"
+	"filesigner/base32encoding"
+	"filesigner/keyid"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ******** Test functions ********
+
+func TestAppendLoad(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), `sub`, `signatures.log`)
+
+	appendEntries(t, logPath, `One`, `Two`, `Three`)
+
+	l, err := Load(logPath)
+	if err != nil {
+		t.Fatalf(`Could not load log: %v`, err)
+	}
+
+	entries := l.Entries()
+	if len(entries) != 3 {
+		t.Fatalf(`Log has %d entries instead of 3`, len(entries))
+	}
+
+	if entries[0].Previous != `` || entries[2].Previous != entries[1].Hash {
+		t.Fatal(`Entries are not chained`)
+	}
+
+	if entries[2].Sequence != 3 || entries[2].ContextId != `Three` {
+		t.Fatalf(`Last entry is wrong: %v`, entries[2])
+	}
+}
+
+func TestFind(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), `signatures.log`)
+
+	appendEntries(t, logPath, `One`, `Two`, `One`)
+
+	l, _ := Load(logPath)
+
+	found, err := l.Find(keyid.KeyWords([]byte(`One`)))
+	if err != nil {
+		t.Fatalf(`Could not find entries: %v`, err)
+	}
+
+	if len(found) != 2 || found[0].Sequence != 1 || found[1].Sequence != 3 {
+		t.Fatalf(`Wrong entries found: %v`, found)
+	}
+
+	found, _ = l.Find(keyid.KeyId([]byte(`Four`)))
+	if len(found) != 0 {
+		t.Fatalf(`Entries found for unknown id: %v`, found)
+	}
+
+	_, err = l.Find(`no id`)
+	if err == nil {
+		t.Fatal(`Invalid id not detected`)
+	}
+}
+
+func TestModification(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), `signatures.log`)
+
+	appendEntries(t, logPath, `One`, `Two`, `Three`)
+
+	content, _ := os.ReadFile(logPath)
+	modifiedContent := strings.Replace(string(content), `"contextId":"Two"`, `"contextId":"Evil"`, 1)
+	_ = os.WriteFile(logPath, []byte(modifiedContent), 0600)
+
+	checkChainError(t, logPath, 2)
+}
+
+func TestDeletion(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), `signatures.log`)
+
+	appendEntries(t, logPath, `One`, `Two`, `Three`)
+
+	content, _ := os.ReadFile(logPath)
+	lines := strings.SplitAfter(string(content), "\n")
+	_ = os.WriteFile(logPath, []byte(lines[0]+lines[2]), 0600)
+
+	checkChainError(t, logPath, 2)
+}
+
+func TestConcurrentAppend(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), `signatures.log`)
+
+	const count = 20
+
+	// All logs are loaded before anything is appended, so each of them is outdated when it appends its entry.
+	logs := make([]*Log, count)
+	for i := range logs {
+		logs[i], _ = Load(logPath)
+	}
+
+	signature := base32encoding.EncodeToString([]byte(`Signature`))
+
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i, l := range logs {
+		wg.Go(func() {
+			contextId := fmt.Sprintf(`Context %d`, i)
+			entry, _ := NewEntry(keyid.KeyId([]byte(contextId)), contextId, `2026-10-19 12:00:00 +02:00`, `host`, signature)
+			errs[i] = l.Append(entry)
+		})
+	}
+	wg.Wait()
+
+	err := errors.Join(errs...)
+	if err != nil {
+		t.Fatalf(`Could not append entries: %v`, err)
+	}
+
+	var l *Log
+	l, err = Load(logPath)
+	if err != nil {
+		t.Fatalf(`Could not load log: %v`, err)
+	}
+
+	if len(l.Entries()) != count {
+		t.Fatalf(`Log has %d entries instead of %d`, len(l.Entries()), count)
+	}
+}
+
+// ******** Private functions ********
+
+// appendEntries appends an entry for each context id to the log.
+func appendEntries(t *testing.T, logPath string, contextIds ...string) {
+	signature := base32encoding.EncodeToString([]byte(`Signature`))
+
+	for _, contextId := range contextIds {
+		l, err := Load(logPath)
+		if err != nil {
+			t.Fatalf(`Could not load log: %v`, err)
+		}
+
+		var entry *Entry
+		entry, err = NewEntry(keyid.KeyId([]byte(contextId)), contextId, `2026-10-19 12:00:00 +02:00`, `host`, signature)
+		if err != nil {
+			t.Fatalf(`Could not create entry: %v`, err)
+		}
+
+		err = l.Append(entry)
+		if err != nil {
+			t.Fatalf(`Could not append entry: %v`, err)
+		}
+	}
+}
+
+// checkChainError checks that the log has a broken chain in the given line.
+func checkChainError(t *testing.T, logPath string, line int) {
+	_, err := Load(logPath)
+
+	var chainErr *ChainError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf(`Broken chain not detected: %v`, err)
+	}
+
+	if chainErr.Line != line {
+		t.Fatalf(`Broken chain reported in line %d instead of %d`, chainErr.Line, line)
+	}
+}
@@ -19,11 +19,12 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.1.0
+// Version: 1.1.1
 //
 // Change history:
 //	   2024-03-03: V1.0.0: Created.
 //	   2026-08-20: V1.1.0: Use "crypto/sha3".
+//	   2026-10-19: V1.1.1: Do not use static buffers.
 //
 
 package stretcher
@@ -64,7 +65,7 @@ func KeyFromBytes(a []byte) []byte {
 // paddingFromBytes generates padding bytes and the original bytes with the length appended
 // from a byte array.
 func paddingFromBytes(a []byte) ([]byte, []byte) {
-	aWithLen := slicehelper.Concat(a, numberhelper.StaticIntAsShortestBigEndianBytes(len(a)))
+	aWithLen := slicehelper.Concat(a, numberhelper.IntAsShortestBigEndianBytes(len(a)))
 
 	// It is quite bizarre that sha3.New512() does not return a hash.Hash.
 	// That was a terrible design decision.
@@ -0,0 +1,95 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package main
+
+import (
+	"filesigner/cmdline"
+	"filesigner/logger"
+	"filesigner/truststore"
+	"fmt"
+)
+
+// ******** Private functions ********
+
+// doTrust executes a subcommand of the "trust" command.
+func doTrust(tcl *cmdline.TrustCommandLine) int {
+	store, err := truststore.Load(tcl.StorePath)
+	if err != nil {
+		logger.PrintErrorf(trustCmdMsgBase+0, `Could not read trust store '%s': %v`, tcl.StorePath, err)
+		return rcProcessError
+	}
+
+	switch tcl.Subcommand {
+	case cmdline.TrustSubcommandAdd:
+		err = store.Add(tcl.Argument, tcl.Label)
+		if err != nil {
+			logger.PrintErrorf(trustCmdMsgBase+1, `Could not add verification id '%s': %v`, tcl.Argument, err)
+			return rcProcessError
+		}
+
+	case cmdline.TrustSubcommandRemove:
+		err = store.Remove(tcl.Argument)
+		if err != nil {
+			logger.PrintErrorf(trustCmdMsgBase+2, `Could not remove '%s': %v`, tcl.Argument, err)
+			return rcProcessError
+		}
+
+	default:
+		return listTrustStore(store)
+	}
+
+	err = store.Save()
+	if err != nil {
+		logger.PrintErrorf(trustCmdMsgBase+3, `Could not write trust store '%s': %v`, store.Path(), err)
+		return rcProcessError
+	}
+
+	if tcl.Subcommand == cmdline.TrustSubcommandAdd {
+		logger.PrintInfof(trustCmdMsgBase+4, `Verification id '%s' added to trust store '%s' with label '%s'`, tcl.Argument, store.Path(), tcl.Label)
+	} else {
+		logger.PrintInfof(trustCmdMsgBase+5, `'%s' removed from trust store '%s'`, tcl.Argument, store.Path())
+	}
+
+	return rcOK
+}
+
+// listTrustStore prints the entries of the trust store.
+func listTrustStore(store *truststore.Store) int {
+	entries := store.Entries()
+	if len(entries) == 0 {
+		logger.PrintInfof(trustCmdMsgBase+6, `Trust store '%s' is empty`, store.Path())
+		return rcOK
+	}
+
+	for _, e := range entries {
+		fmt.Printf("%s  %s  %s\n", e.Id, e.Added, e.Label)
+	}
+
+	return rcOK
+}
@@ -0,0 +1,333 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+// Package truststore implements a local store of trusted verification ids.
+//
+// The store is a json file that is protected by an HMAC with a key in a separate file.
+// Both files are only readable by the owner. So a modification of the store by anybody
+// who can not read the key file is detected.
+package truststore
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha3"
+	"encoding/json"
+	"errors"
+	"filesigner/base32encoding"
+	"filesigner/filehelper"
+	"filesigner/keyid"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ******** Public types ********
+
+// Entry is a trusted verification id.
+type Entry struct {
+	Id    string `json:"id"`
+	Label string `json:"label"`
+	Added string `json:"added"`
+}
+
+// Store is the trust store.
+type Store struct {
+	path    string
+	key     []byte
+	entries []*Entry
+}
+
+// ******** Public variables ********
+
+// ErrModified is returned if the trust store has been modified outside of this program.
+var ErrModified = errors.New(`Trust store has been modified`)
+
+// ErrNotFound is returned if an entry is not present in the trust store.
+var ErrNotFound = errors.New(`Entry not found in trust store`)
+
+// ******** Private types ********
+
+// storeFile is the content of the trust store file.
+type storeFile struct {
+	Format  int      `json:"format"`
+	Entries []*Entry `json:"entries"`
+	Mac     string   `json:"mac"`
+}
+
+// ******** Private constants ********
+
+// storeFormat is the format of the trust store file.
+const storeFormat = 1
+
+// keySuffix is the suffix of the key file name.
+const keySuffix = `.key`
+
+// keySize is the size of the HMAC key.
+const keySize = 32
+
+// maxStoreSize is the maximum size of a trust store file.
+const maxStoreSize = 1 << 20
+
+// dirMode is the file mode of a created trust store directory.
+const dirMode fs.FileMode = 0700
+
+// fileMode is the file mode of the trust store files.
+const fileMode fs.FileMode = 0600
+
+// timeStampFormat is the format of the time when an entry has been added.
+const timeStampFormat = "2006-01-02 15:04:05 Z07:00"
+
+// ******** Type creation ********
+
+// Load reads the trust store with the given path.
+// If the store does not exist, an empty store is returned that is created when it is saved.
+func Load(storePath string) (*Store, error) {
+	result := &Store{path: storePath}
+
+	content, err := readLimitedFile(storePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return result, nil
+		}
+
+		return nil, err
+	}
+
+	result.key, err = readLimitedFile(storePath + keySuffix)
+	if err != nil {
+		return nil, fmt.Errorf(`Could not read key of trust store: %w`, err)
+	}
+
+	var sf storeFile
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&sf)
+	if err != nil {
+		return nil, fmt.Errorf(`Trust store has an invalid format: %w`, err)
+	}
+
+	if sf.Format != storeFormat {
+		return nil, fmt.Errorf(`Trust store has unknown format %d`, sf.Format)
+	}
+
+	var mac []byte
+	mac, err = base32encoding.DecodeFromString(sf.Mac)
+	if err != nil || !hmac.Equal(mac, result.calculateMac(sf.Entries)) {
+		return nil, ErrModified
+	}
+
+	result.entries = sf.Entries
+
+	return result, nil
+}
+
+// ******** Public functions ********
+
+// DefaultPath returns the path of the trust store in the configuration directory of the user.
+func DefaultPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ``, err
+	}
+
+	return filepath.Join(configDir, `filesigner`, `trust.json`), nil
+}
+
+// Path returns the path of the trust store.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add adds a verification id with a label to the trust store.
+// The verification id may be given in any form that keyid.KeyHashFromId accepts.
+func (s *Store) Add(verificationId string, label string) error {
+	trimmedLabel := strings.TrimSpace(label)
+	if len(trimmedLabel) == 0 {
+		return errors.New(`Label must not be empty`)
+	}
+
+	id, err := normalizedId(verificationId)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range s.entries {
+		if e.Id == id {
+			return fmt.Errorf(`Verification id is already trusted with label '%s'`, e.Label)
+		}
+
+		if e.Label == trimmedLabel {
+			return fmt.Errorf(`Label '%s' is already used`, trimmedLabel)
+		}
+	}
+
+	s.entries = append(s.entries, &Entry{
+		Id:    id,
+		Label: trimmedLabel,
+		Added: time.Now().Format(timeStampFormat),
+	})
+
+	return nil
+}
+
+// Remove removes the entry with the given verification id or label from the trust store.
+func (s *Store) Remove(idOrLabel string) error {
+	trimmedIdOrLabel := strings.TrimSpace(idOrLabel)
+
+	// The text may be a label that is not a valid verification id, so an error here is not an error.
+	id, _ := normalizedId(trimmedIdOrLabel)
+
+	index := slices.IndexFunc(s.entries, func(e *Entry) bool {
+		return e.Label == trimmedIdOrLabel || e.Id == id
+	})
+	if index < 0 {
+		return ErrNotFound
+	}
+
+	s.entries = slices.Delete(s.entries, index, index+1)
+
+	return nil
+}
+
+// Entries returns the entries of the trust store sorted by their labels.
+func (s *Store) Entries() []*Entry {
+	result := slices.Clone(s.entries)
+	slices.SortFunc(result, func(a *Entry, b *Entry) int {
+		return strings.Compare(a.Label, b.Label)
+	})
+
+	return result
+}
+
+// Ids returns the trusted verification ids.
+func (s *Store) Ids() []string {
+	result := make([]string, len(s.entries))
+	for i, e := range s.entries {
+		result[i] = e.Id
+	}
+
+	return result
+}
+
+// Save writes the trust store.
+// The directory of the store and the key are created, if they do not exist.
+func (s *Store) Save() error {
+	err := os.MkdirAll(filepath.Dir(s.path), dirMode)
+	if err != nil {
+		return err
+	}
+
+	err = s.ensureKey()
+	if err != nil {
+		return err
+	}
+
+	if s.entries == nil {
+		s.entries = make([]*Entry, 0)
+	}
+
+	var content []byte
+	content, err = json.MarshalIndent(&storeFile{
+		Format:  storeFormat,
+		Entries: s.entries,
+		Mac:     base32encoding.EncodeToString(s.calculateMac(s.entries)),
+	}, ``, `   `)
+	if err != nil {
+		return err
+	}
+
+	return filehelper.WriteFileAtomic(s.path, content, fileMode)
+}
+
+// ******** Private functions ********
+
+// ensureKey reads the key of the trust store or creates it, if it does not exist.
+func (s *Store) ensureKey() error {
+	if s.key != nil {
+		return nil
+	}
+
+	keyPath := s.path + keySuffix
+
+	var err error
+	s.key, err = readLimitedFile(keyPath)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	s.key = make([]byte, keySize)
+	_, _ = rand.Read(s.key)
+
+	return filehelper.WriteFileAtomic(keyPath, s.key, fileMode)
+}
+
+// calculateMac calculates the HMAC of the entries.
+func (s *Store) calculateMac(entries []*Entry) []byte {
+	mac := hmac.New(func() hash.Hash { return sha3.New256() }, s.key)
+
+	// Marshalling a slice of structures is deterministic, so the result can be used as the input of the HMAC.
+	content, _ := json.Marshal(entries)
+	_, _ = mac.Write(content)
+
+	return mac.Sum(nil)
+}
+
+// normalizedId returns the verification id in the form that is stored.
+func normalizedId(verificationId string) (string, error) {
+	keyHash, err := keyid.KeyHashFromId(verificationId)
+	if err != nil {
+		return ``, err
+	}
+
+	return base32encoding.EncodeKeyWithCheck(keyHash), nil
+}
+
+// readLimitedFile reads a file that must not be larger than the maximum store size.
+func readLimitedFile(filePath string) ([]byte, error) {
+	size, err := filehelper.FileSize(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if size > maxStoreSize {
+		return nil, fmt.Errorf(`File '%s' is too large`, filePath)
+	}
+
+	return os.ReadFile(filePath)
+}
@@ -0,0 +1,158 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package truststore
+
+import (
+	"errors"
+	"filesigner/keyid"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ******** Test functions ********
+
+func TestAddSaveLoad(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), `sub`, `trust.json`)
+	id := keyid.KeyId([]byte(`Test`))
+
+	s, err := Load(storePath)
+	if err != nil {
+		t.Fatalf(`Could not load missing store: %v`, err)
+	}
+
+	err = s.Add(id, `Vendor release`)
+	if err != nil {
+		t.Fatalf(`Could not add id: %v`, err)
+	}
+
+	err = s.Save()
+	if err != nil {
+		t.Fatalf(`Could not save store: %v`, err)
+	}
+
+	s, err = Load(storePath)
+	if err != nil {
+		t.Fatalf(`Could not load store: %v`, err)
+	}
+
+	ids := s.Ids()
+	if len(ids) != 1 || ids[0] != id {
+		t.Fatalf(`Store has wrong ids: %v`, ids)
+	}
+
+	entries := s.Entries()
+	if entries[0].Label != `Vendor release` {
+		t.Fatalf(`Entry has wrong label: '%s'`, entries[0].Label)
+	}
+}
+
+func TestAddWordForm(t *testing.T) {
+	s, _ := Load(filepath.Join(t.TempDir(), `trust.json`))
+
+	err := s.Add(keyid.KeyWords([]byte(`Test`)), `Words`)
+	if err != nil {
+		t.Fatalf(`Could not add id in word form: %v`, err)
+	}
+
+	if s.Ids()[0] != keyid.KeyId([]byte(`Test`)) {
+		t.Fatalf(`Id has not been normalized: '%s'`, s.Ids()[0])
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	s, _ := Load(filepath.Join(t.TempDir(), `trust.json`))
+	_ = s.Add(keyid.KeyId([]byte(`Test`)), `Label`)
+
+	err := s.Add(keyid.KeyWords([]byte(`Test`)), `Other label`)
+	if err == nil {
+		t.Fatal(`Duplicate id not detected`)
+	}
+
+	err = s.Add(keyid.KeyId([]byte(`Other`)), `Label`)
+	if err == nil {
+		t.Fatal(`Duplicate label not detected`)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	s, _ := Load(filepath.Join(t.TempDir(), `trust.json`))
+	_ = s.Add(keyid.KeyId([]byte(`One`)), `One`)
+	_ = s.Add(keyid.KeyId([]byte(`Two`)), `Two`)
+
+	err := s.Remove(`One`)
+	if err != nil {
+		t.Fatalf(`Could not remove by label: %v`, err)
+	}
+
+	err = s.Remove(keyid.KeyWords([]byte(`Two`)))
+	if err != nil {
+		t.Fatalf(`Could not remove by id: %v`, err)
+	}
+
+	err = s.Remove(`Three`)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf(`Missing entry not detected: %v`, err)
+	}
+
+	if len(s.Ids()) != 0 {
+		t.Fatal(`Store is not empty`)
+	}
+}
+
+func TestModification(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), `trust.json`)
+	s, _ := Load(storePath)
+	_ = s.Add(keyid.KeyId([]byte(`Test`)), `Label`)
+	_ = s.Save()
+
+	content, _ := os.ReadFile(storePath)
+	modifiedContent := strings.Replace(string(content), keyid.KeyId([]byte(`Test`)), keyid.KeyId([]byte(`Evil`)), 1)
+	_ = os.WriteFile(storePath, []byte(modifiedContent), 0600)
+
+	_, err := Load(storePath)
+	if !errors.Is(err, ErrModified) {
+		t.Fatalf(`Modification not detected: %v`, err)
+	}
+}
+
+func TestMissingKey(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), `trust.json`)
+	s, _ := Load(storePath)
+	_ = s.Add(keyid.KeyId([]byte(`Test`)), `Label`)
+	_ = s.Save()
+
+	_ = os.Remove(storePath + keySuffix)
+
+	_, err := Load(storePath)
+	if err == nil {
+		t.Fatal(`Missing key not detected`)
+	}
+}
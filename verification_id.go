@@ -0,0 +1,216 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 2.1.1
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Compare verification ids as hash values.
+//    2026-10-19: V1.2.0: Report typos in verification ids.
+//    2026-10-19: V1.3.0: Look up verification ids in the trust store.
+//    2026-10-19: V2.0.0: Move verification id check to api package.
+//    2026-10-19: V2.1.0: Read id list only once for more than one signatures file.
+//    2026-10-19: V2.1.1: Report a located typo as a guess.
+//
+
+package main
+
+import (
+	"errors"
+	"filesigner/api"
+	"filesigner/base32encoding"
+	"filesigner/cmdline"
+	"filesigner/filehelper"
+	"filesigner/idlist"
+	"filesigner/logger"
+	"filesigner/truststore"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ******** Private constants ********
+
+// maxIdFileSize is the maximum size of a file that contains a verification id.
+const maxIdFileSize = 4096
+
+// idListTimeout is the time after which the download of an id list is aborted.
+const idListTimeout = 30 * time.Second
+
+// ******** Private functions ********
+
+// makeVerificationIdResolver creates the verification id resolver from the verification id parameter or
+// from the verification id options. Exactly one of them must be present.
+func makeVerificationIdResolver(parameterVerificationId string, vcl *cmdline.VerifyCommandLine) (api.VerificationIdResolver, int) {
+	sourceCount := vcl.IdSourceCount()
+	if len(parameterVerificationId) != 0 {
+		sourceCount++
+	}
+
+	switch sourceCount {
+	case 0:
+		return makeTrustStoreResolver(vcl.TrustStorePath), rcOK
+
+	case 1:
+		// This is the only valid case.
+
+	default:
+		return nil, printUsageError(verificationIdMsgBase+0, `Verification id must not be specified together with a verification id option`)
+	}
+
+	if len(vcl.TrustStorePath) != 0 {
+		return nil, printUsageError(verificationIdMsgBase+8, `Option 'trust-store' must not be specified together with a verification id`)
+	}
+
+	// Each signatures file has its own verification id, so they can only be taken from an id list.
+	if vcl.HasManySignaturesFiles() && len(vcl.IdUrl) == 0 {
+		return nil, printUsageError(verificationIdMsgBase+4, `Only option 'id-url' or the trust store can be used for more than one signatures file`)
+	}
+
+	var verificationId string
+	var err error
+	switch {
+	case len(parameterVerificationId) != 0:
+		verificationId = parameterVerificationId
+
+	case len(vcl.IdFile) != 0:
+		verificationId, err = readIdFile(vcl.IdFile)
+		if err != nil {
+			logger.PrintErrorf(verificationIdMsgBase+1, `Could not read verification id from file '%s': %v`, vcl.IdFile, err)
+			return nil, rcProcessError
+		}
+
+	case len(vcl.IdEnv) != 0:
+		verificationId = strings.TrimSpace(os.Getenv(vcl.IdEnv))
+		if len(verificationId) == 0 {
+			logger.PrintErrorf(verificationIdMsgBase+2, `Environment variable '%s' does not contain a verification id`, vcl.IdEnv)
+			return nil, rcProcessError
+		}
+
+	default:
+		return makeIdListResolver(vcl.IdUrl, vcl.HasManySignaturesFiles()), rcOK
+	}
+
+	return api.FixedVerificationIds(verificationId), rcOK
+}
+
+// makeIdListResolver creates a verification id resolver that reads the verification ids from an id list.
+// If keepList is true, the id list is only read once and used for all context ids.
+func makeIdListResolver(idUrl string, keepList bool) api.VerificationIdResolver {
+	var list *idlist.IdList
+
+	return func(contextId string) ([]string, error) {
+		if list == nil || !keepList {
+			logger.PrintInfof(verificationIdMsgBase+3, `Reading verification ids from '%s'`, idUrl)
+
+			var err error
+			list, err = idlist.Load(idUrl, &http.Client{Timeout: idListTimeout})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result := list.Ids(contextId)
+		if len(result) == 0 {
+			return nil, fmt.Errorf(`Id list does not contain context id '%s'`, contextId)
+		}
+
+		return result, nil
+	}
+}
+
+// makeTrustStoreResolver creates a verification id resolver that reads the trusted verification ids from a trust store.
+// The verification id matches the context id and the public key of the signatures file if it is one of the trusted ids.
+func makeTrustStoreResolver(storePath string) api.VerificationIdResolver {
+	return func(string) ([]string, error) {
+		if len(storePath) == 0 {
+			var err error
+			storePath, err = truststore.DefaultPath()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		logger.PrintInfof(verificationIdMsgBase+9, `Looking up verification id in trust store '%s'`, storePath)
+
+		store, err := truststore.Load(storePath)
+		if err != nil {
+			return nil, err
+		}
+
+		result := store.Ids()
+		if len(result) == 0 {
+			return nil, errors.New(`Trust store does not contain any verification ids`)
+		}
+
+		return result, nil
+	}
+}
+
+// printInvalidVerificationId prints the error message for a verification id that can not be decoded.
+// A typo is reported as such, so that it is not mistaken for a manipulation.
+func printInvalidVerificationId(verificationId string, err error) {
+	var typoErr *base32encoding.KeyTypoError
+	switch {
+	case errors.As(err, &typoErr):
+		if typoErr.IsGuess {
+			logger.PrintErrorf(verificationIdMsgBase+6, `Verification id '%s' has a typo, probably in group %d`, verificationId, typoErr.Group)
+		} else {
+			logger.PrintErrorf(verificationIdMsgBase+6, `Verification id '%s' has a typo in group %d`, verificationId, typoErr.Group)
+		}
+
+	case errors.Is(err, base32encoding.ErrKeyTypos):
+		logger.PrintErrorf(verificationIdMsgBase+7, `Verification id '%s' has more than one typo`, verificationId)
+
+	default:
+		logger.PrintErrorf(verificationIdMsgBase+5, `Verification id '%s' is invalid: %v`, verificationId, err)
+	}
+}
+
+// readIdFile reads a verification id from a file.
+func readIdFile(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return ``, err
+	}
+	defer filehelper.CloseFile(f)
+
+	var content []byte
+	content, err = io.ReadAll(io.LimitReader(f, maxIdFileSize+1))
+	if err != nil {
+		return ``, err
+	}
+
+	if len(content) > maxIdFileSize {
+		return ``, errors.New(`File is too large`)
+	}
+
+	result := strings.TrimSpace(string(content))
+	if len(result) == 0 {
+		return ``, errors.New(`File is empty`)
+	}
+
+	return result, nil
+}
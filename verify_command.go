@@ -1,5 +1,5 @@
 //
-// SPDX-FileCopyrightText: Copyright 2024-2025 Frank Schwab
+// SPDX-FileCopyrightText: Copyright 2024-2026 Frank Schwab
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,7 +20,7 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.4.0
+// Version: 2.4.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
@@ -29,87 +29,183 @@
 //    2025-03-01: V1.2.1: Correct message levels of verification success messages.
 //    2025-03-01: V1.3.0: Add message base.
 //    2025-03-01: V1.4.0: Correct handling of os.Stat errors.
+//    2026-10-19: V1.5.0: Verify entries of archives.
+//    2026-10-19: V1.6.0: Verify archives with embedded signatures file, use command line object.
+//    2026-10-19: V1.7.0: Use hash options from signature data.
+//    2026-10-19: V1.8.0: Verify data from stdin.
+//    2026-10-19: V1.9.0: Get valid verification ids from a resolver.
+//    2026-10-19: V1.10.0: Accept verification ids in word form.
+//    2026-10-19: V1.11.0: Check revocation list.
+//    2026-10-19: V1.12.0: Check expiry of signatures.
+//    2026-10-19: V1.13.0: Check metadata of files.
+//    2026-10-19: V1.14.0: Check symbolic links.
+//    2026-10-19: V2.0.0: Use api package.
+//    2026-10-19: V2.1.0: Separate verification and printing of results for the watch command.
+//    2026-10-19: V2.2.0: Send notification if the verification is not successful.
+//    2026-10-19: V2.3.0: Write metrics file.
+//    2026-10-19: V2.4.0: Verify more than one signatures file.
 //
 
 package main
 
 import (
+	"context"
 	"errors"
-	"filesigner/base32encoding"
-	"filesigner/filehasher"
-	"filesigner/filesignature"
-	"filesigner/hashsignature"
+	"filesigner/api"
+	"filesigner/cmdline"
 	"filesigner/logger"
-	"filesigner/maphelper"
-	"filesigner/signaturefile"
-	"filesigner/signaturehandler"
-	"filesigner/stretcher"
-	"filesigner/stringhelper"
 	"filesigner/texthelper"
-	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
-// ******** Private constants ********
+// ******** Private types ********
 
-// errMsgCouldNotConvert is the error message for a base32 conversion error.
-const errMsgCouldNotConvert = `Could not convert %s to bytes: %v`
+// verificationResult is the result of the verification of one signatures file.
+type verificationResult struct {
+	signaturesFilePath string
+	// report is nil, if the signatures file could not be verified.
+	report    *api.Report
+	rc        int
+	startTime time.Time
+	duration  time.Duration
+}
 
 // ******** Private functions ********
 
-// doVerification verifies a signatures file.
-func doVerification(signaturesFileName string, parameterVerificationId string) int {
-	logger.PrintInfof(verifyCmdMsgBase+0, `Reading signatures file '%s'`, signaturesFileName)
+// doVerification verifies the signatures files and returns the worst return code.
+// If an archive path is given, the entries of the archive are verified instead of the files.
+// If the archive contains the signatures file, this embedded signatures file is used
+// and the archive must not contain any files that are not signed.
+func doVerification(resolver api.VerificationIdResolver, vcl *cmdline.VerifyCommandLine) int {
+	results := make([]*verificationResult, 0, len(vcl.SignaturesFilePaths))
+	rc := rcOK
+	for _, signaturesFilePath := range vcl.SignaturesFilePaths {
+		if vcl.HasManySignaturesFiles() {
+			logger.PrintInfof(verifyCmdMsgBase+15, `Verifying signatures file '%s'`, signaturesFilePath)
+		}
 
-	signatureData, err := signaturefile.ReadJson(signaturesFileName)
-	if err != nil {
-		logger.PrintErrorf(verifyCmdMsgBase+1, `Error reading signatures file: %v`, err)
-		return rcProcessError
+		result := verifySignaturesFile(resolver, vcl, signaturesFilePath)
+		results = append(results, result)
+		rc = max(rc, result.rc)
 	}
 
-	var publicKeyBytes []byte
-	publicKeyBytes, err = base32encoding.DecodeFromString(signatureData.PublicKey)
-	if err != nil {
-		logger.PrintErrorf(verifyCmdMsgBase+2, errMsgCouldNotConvert, `public key`, err)
-		return rcProcessError
+	if vcl.HasManySignaturesFiles() {
+		printCombinedSummary(results)
 	}
 
-	var dataSignature []byte
-	dataSignature, err = base32encoding.DecodeFromString(signatureData.DataSignature)
-	if err != nil {
-		logger.PrintErrorf(verifyCmdMsgBase+3, errMsgCouldNotConvert, `data signature`, err)
-		return rcProcessError
+	if len(vcl.MetricsFilePath) != 0 {
+		writeMetrics(vcl.MetricsFilePath, results)
 	}
 
-	var hashVerifier hashsignature.HashVerifier
-	hashVerifier, err = getHashVerifier(signatureData, publicKeyBytes)
-	if err != nil {
-		logger.PrintErrorf(verifyCmdMsgBase+4, `Error getting hash verifier: %v`, err)
-		return rcProcessError
+	return rc
+}
+
+// verifySignaturesFile verifies one signatures file.
+// The files of a signatures file in another directory are verified relative to this directory.
+func verifySignaturesFile(resolver api.VerificationIdResolver,
+	vcl *cmdline.VerifyCommandLine,
+	signaturesFilePath string) *verificationResult {
+	dirPath, signaturesFileName := filepath.Split(signaturesFilePath)
+	options := &api.VerifyOptions{
+		SignaturesFileName:     signaturesFileName,
+		ArchivePath:            vcl.ArchivePath,
+		ResolveVerificationIds: resolver,
+		RevocationsPath:        vcl.RevocationsPath,
+		RevocationsId:          vcl.RevocationsId,
+		VerificationTime:       vcl.VerificationTime,
+	}
+
+	if len(dirPath) != 0 {
+		options.FS = os.DirFS(dirPath)
+	}
+
+	if len(vcl.StdinDataName) != 0 {
+		options.DataName = vcl.StdinDataName
+		options.DataReader = os.Stdin
+	}
+
+	result := &verificationResult{signaturesFilePath: signaturesFilePath, startTime: time.Now()}
+
+	var err error
+	result.report, result.rc, err = verifyAndPrint(options)
+	result.duration = time.Since(result.startTime)
+
+	if result.rc != rcOK && len(vcl.NotifyUrl) != 0 {
+		sendNotification(vcl, signaturesFilePath, result.report, result.rc, err)
 	}
 
-	contextKey := stretcher.KeyFromBytes(stringhelper.UnsafeStringBytes(signatureData.ContextId))
-	var ok bool
-	ok, err = signatureData.Verify(hashVerifier, contextKey, dataSignature)
-	if err == nil {
-		if !ok {
-			logger.PrintError(verifyCmdMsgBase+5, `Signatures file has been modified`)
-			return rcProcessError
+	return result
+}
+
+// printCombinedSummary prints the summary of the verification of more than one signatures file.
+func printCombinedSummary(results []*verificationResult) {
+	var successCount, warningCount, errorCount int
+	for _, result := range results {
+		switch result.rc {
+		case rcOK:
+			successCount++
+
+		case rcProcessWarning:
+			warningCount++
+
+		default:
+			errorCount++
+			logger.PrintErrorf(verifyCmdMsgBase+16, `Verification of signatures file '%s' unsuccessful`, result.signaturesFilePath)
 		}
+	}
+
+	logger.PrintInfof(verifyCmdMsgBase+19,
+		`Verification of %d signatures file%s: %d successful, %d with warnings, %d unsuccessful`,
+		len(results),
+		texthelper.GetCountEnding(len(results)),
+		successCount,
+		warningCount,
+		errorCount)
+}
+
+// verifyAndPrint verifies the files with the given options, prints the results and returns the report and the return code.
+// If the signatures file could not be verified, the report is nil and the error describes the problem.
+func verifyAndPrint(options *api.VerifyOptions) (*api.Report, int, error) {
+	report, err := api.Verify(context.Background(), options)
+	if err != nil {
+		return nil, printVerifyError(err), err
+	}
+
+	if len(report.EmbeddingArchivePath) != 0 {
+		logger.PrintInfof(verifyCmdMsgBase+17, `Signatures file '%s' has been read from archive '%s'`, report.SignaturesFileName, report.EmbeddingArchivePath)
 	} else {
-		logger.PrintErrorf(verifyCmdMsgBase+6, `Error verifying signatures file data signature: %v`, err)
-		return rcProcessError
+		logger.PrintInfof(verifyCmdMsgBase+0, `Signatures file '%s' has been read`, report.SignaturesFileName)
+	}
+
+	if len(options.RevocationsPath) != 0 {
+		logger.PrintInfof(revokeCmdMsgBase+6, `Verification id is not in revocation list '%s'`, options.RevocationsPath)
 	}
 
-	if makeVerificationId(signatureData, publicKeyBytes) != parameterVerificationId {
-		logger.PrintError(verifyCmdMsgBase+7, `Invalid verification id`)
-		return rcProcessError
+	printMetaData(&report.SignatureInfo)
+
+	for _, warning := range report.Warnings {
+		logger.PrintWarning(warningMsgNumber(warning), warning.Error())
+	}
+
+	printFileResults(report.Files)
+
+	if report.ModesNotCompared {
+		logger.PrintInfo(fileMetadataMsgBase+2, `File modes are not compared on this platform`)
 	}
 
-	printMetaData(signatureData, publicKeyBytes)
+	successCount := report.SuccessCount()
+	errorCount := report.ErrorCount()
 
-	successCount, errorCount, rc := verifyFiles(contextKey, signatureData, hashVerifier)
+	rc := rcOK
+	if report.HasWarnings() {
+		rc = rcProcessWarning
+	}
+
+	if errorCount > 0 {
+		rc = rcProcessError
+	}
 
 	successEnding := texthelper.GetCountEnding(successCount)
 	errorEnding := texthelper.GetCountEnding(errorCount)
@@ -125,82 +221,81 @@ func doVerification(signaturesFileName string, parameterVerificationId string) i
 		logger.PrintInfof(verifyCmdMsgBase+10, `Verification of %d file%s successful and %d file%s unsuccessful`, successCount, successEnding, errorCount, errorEnding)
 	}
 
-	return rc
+	return report, rc, nil
 }
 
-// verifyFiles verifies the signatures of the files in the signature data.
-func verifyFiles(contextBytes []byte,
-	signatureData *signaturehandler.SignatureData,
-	hashVerifier hashsignature.HashVerifier) (int, int, int) {
-	filePaths, rc := getExistingFiles(maphelper.Keys(signatureData.FileSignatures))
+// printVerifyError prints an error that prevented the verification of the files and returns the return code.
+func printVerifyError(err error) int {
+	var idErr *api.InvalidVerificationIdError
+	var revokedErr *api.RevokedError
+	switch {
+	case errors.As(err, &idErr):
+		printInvalidVerificationId(idErr.VerificationId, idErr.Err)
 
-	if len(filePaths) == 0 {
-		logger.PrintWarning(verifyCmdMsgBase+11, `No files from signatures file present`)
-		return 0, 0, rcProcessWarning
-	}
+	case errors.As(err, &revokedErr):
+		logger.PrintError(revokeCmdMsgBase+9, err.Error())
+		return rcRevoked
 
-	hashList := filehasher.FileHashes(filePaths, contextBytes)
-	if existHashErrors(hashList) {
-		return 0, 0, rcProcessError
-	}
+	case errors.Is(err, api.ErrSignaturesModified):
+		logger.PrintError(verifyCmdMsgBase+5, err.Error())
 
-	successList, errorList := filesignature.VerifyFileHashes(hashVerifier, signatureData.FileSignatures, hashList)
+	case errors.Is(err, api.ErrInvalidVerificationId):
+		logger.PrintError(verifyCmdMsgBase+7, err.Error())
 
-	successCount := len(successList)
-	if successCount > 0 {
-		printSuccessList(`Verification`, successList)
-	}
+	case errors.Is(err, api.ErrExpired):
+		logger.PrintError(signatureTimeMsgBase+2, err.Error())
 
-	errorCount := len(errorList)
-	if errorCount > 0 {
-		printErrorList(errorList)
-		rc = rcProcessError
+	default:
+		logger.PrintError(verifyCmdMsgBase+1, err.Error())
 	}
 
-	return successCount, errorCount, rc
+	return rcProcessError
 }
 
-// getHashVerifier constructs the hash verifier and the key id from the signature data.
-func getHashVerifier(signatureData *signaturehandler.SignatureData, publicKeyBytes []byte) (hashsignature.HashVerifier, error) {
-	var err error
-	var hashVerifier hashsignature.HashVerifier
-	if signatureData.SignatureType == signaturehandler.SignatureTypeEd25519 {
-		hashVerifier, err = hashsignature.NewEd25519HashVerifier(publicKeyBytes)
-	} else {
-		hashVerifier, err = hashsignature.NewEcDsaP521HashVerifier(publicKeyBytes)
-	}
-	if err != nil {
-		return nil, fmt.Errorf(`Could not create hash verifier: %w`, err)
-	}
+// printFileResults prints the results of the verification of the files.
+func printFileResults(fileResults []*api.FileResult) {
+	for _, fr := range fileResults {
+		switch fr.Status {
+		case api.FileStatusOK:
+			logger.PrintInfof(commonMsgBase+0, `Verification succeeded for file '%s'`, fr.FilePath)
 
-	return hashVerifier, nil
-}
+		case api.FileStatusMissing:
+			logger.PrintWarning(verifyCmdMsgBase+12, fr.Err.Error())
 
-// getExistingFiles gets the files from a signature list that exist in the directory that is to be verified.
-func getExistingFiles(filePaths []string) ([]string, int) {
-	rc := rcOK
+		case api.FileStatusDirectory:
+			logger.PrintWarning(verifyCmdMsgBase+14, fr.Err.Error())
+
+		case api.FileStatusFailed:
+			logger.PrintError(verifyCmdMsgBase+13, fr.Err.Error())
 
-	result := make([]string, 0, len(filePaths))
-	for _, fp := range filePaths {
-		nfp := filepath.FromSlash(fp)
-		fi, err := os.Stat(nfp)
-		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				logger.PrintWarningf(verifyCmdMsgBase+12, `File '%s' in signatures file does not exist`, nfp)
-				rc = max(rc, rcProcessWarning)
-			} else {
-				logger.PrintErrorf(verifyCmdMsgBase+13, `Error checking if file '%s' in signatures file exists: %v`, nfp, err)
-				rc = rcProcessError
-			}
-		} else {
-			if fi.IsDir() {
-				logger.PrintWarningf(verifyCmdMsgBase+14, `'%s' in signatures file is a directory`, nfp)
-				rc = max(rc, rcProcessWarning)
-			} else {
-				result = append(result, nfp)
-			}
+		case api.FileStatusModified:
+			logger.PrintError(commonMsgBase+1, fr.Err.Error())
+
+		case api.FileStatusMetadataModified:
+			logger.PrintError(fileMetadataMsgBase+0, fr.Err.Error())
+
+		case api.FileStatusSymlinkChanged:
+			logger.PrintError(symlinksMsgBase+0, fr.Err.Error())
+
+		case api.FileStatusUnsigned:
+			logger.PrintError(verifyCmdMsgBase+18, fr.Err.Error())
 		}
 	}
+}
 
-	return result, rc
+// warningMsgNumber returns the message number of a warning of a verification.
+func warningMsgNumber(warning error) byte {
+	switch {
+	case errors.Is(warning, api.ErrInvalidTimestamp):
+		return signatureTimeMsgBase + 0
+
+	case errors.Is(warning, api.ErrFutureTimestamp):
+		return signatureTimeMsgBase + 1
+
+	case errors.Is(warning, api.ErrMetadataNotChecked):
+		return fileMetadataMsgBase + 1
+
+	default:
+		return verifyCmdMsgBase + 11
+	}
 }
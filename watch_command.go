@@ -0,0 +1,185 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//    2026-10-19: V1.1.0: Return the worst return code of all verifications.
+//
+
+package main
+
+import (
+	"context"
+	"filesigner/api"
+	"filesigner/cmdline"
+	"filesigner/filewatcher"
+	"filesigner/logger"
+	"filesigner/set"
+	"filesigner/texthelper"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ******** Private constants ********
+
+// watchSettleTime is the time that is waited after a change, so that further changes are verified together with it.
+const watchSettleTime = 500 * time.Millisecond
+
+// ******** Private functions ********
+
+// doWatch verifies all files of a signatures file and then verifies each file again as soon as it is changed.
+// All files are verified again after each interval, after changes have been lost and after the signatures file has been changed.
+// Watching ends when the signatures file can not be verified any more or the program is interrupted.
+// The return code is the worst return code of all verifications, so that failed verifications can be seen after watching.
+func doWatch(resolver api.VerificationIdResolver, wcl *cmdline.WatchCommandLine) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.PrintInfof(watchCmdMsgBase+0, `Watching files of signatures file '%s' with full verification every %s`, wcl.SignaturesFileName, wcl.Interval)
+
+	worstRc := rcOK
+	for {
+		// The verification ids are only resolved once per full verification, so that an id list is not read for every change.
+		options := &api.VerifyOptions{
+			SignaturesFileName:     wcl.SignaturesFileName,
+			ResolveVerificationIds: cachedResolver(resolver),
+			RevocationsPath:        wcl.RevocationsPath,
+			RevocationsId:          wcl.RevocationsId,
+		}
+
+		report, rc, _ := verifyAndPrint(options)
+		worstRc = max(worstRc, rc)
+		if report == nil {
+			return worstRc
+		}
+
+		filePaths := make([]string, 0, len(report.Files)+1)
+		for _, fr := range report.Files {
+			filePaths = append(filePaths, fr.FilePath)
+		}
+		filePaths = append(filePaths, wcl.SignaturesFileName)
+
+		watcher, err := filewatcher.New(filePaths)
+		if err != nil {
+			logger.PrintErrorf(watchCmdMsgBase+1, `Could not watch files: %v`, err)
+			return max(worstRc, rcProcessError)
+		}
+
+		var isStopped bool
+		isStopped, rc = watchFiles(ctx, watcher, options, wcl.Interval)
+		_ = watcher.Close()
+		worstRc = max(worstRc, rc)
+
+		if isStopped {
+			logger.PrintInfo(watchCmdMsgBase+2, `Watching stopped`)
+			return worstRc
+		}
+	}
+}
+
+// watchFiles verifies the files that are reported by the watcher until all files have to be verified again.
+// It returns true, if watching has to be stopped, and the worst return code of the verifications of changed files.
+func watchFiles(ctx context.Context,
+	watcher *filewatcher.Watcher,
+	options *api.VerifyOptions,
+	interval time.Duration) (bool, int) {
+	intervalTimer := time.NewTimer(interval)
+	defer intervalTimer.Stop()
+
+	changedPaths := set.New[string]()
+	worstRc := rcOK
+
+	// settleChannel is nil while there are no changed files, so that it never fires.
+	var settleChannel <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true, worstRc
+
+		case filePath, isOpen := <-watcher.Events:
+			if !isOpen {
+				return false, worstRc
+			}
+
+			if filePath == options.SignaturesFileName {
+				logger.PrintWarningf(watchCmdMsgBase+3, `Signatures file '%s' has been changed`, filePath)
+				return false, worstRc
+			}
+
+			changedPaths.Add(filePath)
+			if settleChannel == nil {
+				settleChannel = time.After(watchSettleTime)
+			}
+
+		case err, isOpen := <-watcher.Errors:
+			if isOpen {
+				logger.PrintWarningf(watchCmdMsgBase+4, `Error watching files: %v`, err)
+			}
+
+			return false, worstRc
+
+		case <-settleChannel:
+			settleChannel = nil
+
+			logger.PrintInfof(watchCmdMsgBase+5, `Verifying %d changed file%s`, changedPaths.Size(), texthelper.GetCountEnding(changedPaths.Size()))
+
+			options.FilePaths = changedPaths.Elements()
+			report, rc, _ := verifyAndPrint(options)
+			options.FilePaths = nil
+			changedPaths.Clear()
+			worstRc = max(worstRc, rc)
+
+			if report == nil {
+				return true, worstRc
+			}
+
+		case <-intervalTimer.C:
+			return false, worstRc
+		}
+	}
+}
+
+// cachedResolver returns a verification id resolver that resolves the verification ids of each context id only once.
+func cachedResolver(resolver api.VerificationIdResolver) api.VerificationIdResolver {
+	cache := make(map[string][]string)
+
+	return func(contextId string) ([]string, error) {
+		result, isCached := cache[contextId]
+		if isCached {
+			return result, nil
+		}
+
+		result, err := resolver(contextId)
+		if err != nil {
+			return nil, err
+		}
+
+		cache[contextId] = result
+
+		return result, nil
+	}
+}
@@ -0,0 +1,127 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+// Package wordencoding implements the encoding of byte slices as words of the PGP word list.
+//
+// Bytes at even positions are encoded with a two-syllable word and bytes at odd positions
+// with a three-syllable word. So swapped, repeated or omitted words are detected when decoding.
+package wordencoding
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ******** Private constants ********
+
+// wordSeparator separates the words of an encoded byte slice.
+const wordSeparator = `-`
+
+// ******** Private types ********
+
+// wordValue is the decoded value of a word.
+type wordValue struct {
+	value  byte
+	isEven bool
+}
+
+// ******** Private variables ********
+
+// wordValues maps each word to its value.
+var wordValues = makeWordValues()
+
+// ******** Public functions ********
+
+// Encode encodes a byte slice as words that are separated by hyphens.
+func Encode(b []byte) string {
+	var result strings.Builder
+
+	for i, v := range b {
+		if i > 0 {
+			result.WriteString(wordSeparator)
+		}
+
+		if isEvenPosition(i) {
+			result.WriteString(evenWords[v])
+		} else {
+			result.WriteString(oddWords[v])
+		}
+	}
+
+	return result.String()
+}
+
+// Decode decodes words into a byte slice.
+// The words may be separated by hyphens or white space. The case of the letters is irrelevant.
+func Decode(s string) ([]byte, error) {
+	words := strings.FieldsFunc(strings.ToLower(s), isSeparator)
+	if len(words) == 0 {
+		return nil, fmt.Errorf(`No words present`)
+	}
+
+	result := make([]byte, len(words))
+	for i, word := range words {
+		wv, found := wordValues[word]
+		if !found {
+			return nil, fmt.Errorf(`Word %d ('%s') is not a valid word`, i+1, word)
+		}
+
+		if wv.isEven != isEvenPosition(i) {
+			return nil, fmt.Errorf(`Word %d ('%s') is at the wrong position (words may have been swapped, repeated or omitted)`, i+1, word)
+		}
+
+		result[i] = wv.value
+	}
+
+	return result, nil
+}
+
+// ******** Private functions ********
+
+// makeWordValues builds the map from words to their values.
+func makeWordValues() map[string]wordValue {
+	result := make(map[string]wordValue, len(evenWords)+len(oddWords))
+
+	for i := range evenWords {
+		result[evenWords[i]] = wordValue{value: byte(i), isEven: true}
+		result[oddWords[i]] = wordValue{value: byte(i), isEven: false}
+	}
+
+	return result
+}
+
+// isEvenPosition checks if a position is even.
+func isEvenPosition(i int) bool {
+	return i&1 == 0
+}
+
+// isSeparator checks if a rune separates words.
+func isSeparator(r rune) bool {
+	return r == '-' || unicode.IsSpace(r)
+}
@@ -0,0 +1,110 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-19: V1.0.0: Created.
+//
+
+package wordencoding
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// ******** Private constants ********
+
+// testLoopCount contains the no. of times a test is repeated.
+const testLoopCount = 1_000
+
+// ******** Test functions ********
+
+func TestKnownValue(t *testing.T) {
+	b, _ := hex.DecodeString(`E58294F2E9A227486E8B061B31CC528FD7FA3F19`)
+	expected := `topmost-istanbul-pluto-vagabond-treadmill-pacific-brackish-dictator-goldfish-medusa-` +
+		`afflict-bravado-chatter-revolver-dupont-midsummer-stopwatch-whimsical-cowbell-bottomless`
+
+	encoded := Encode(b)
+	if encoded != expected {
+		t.Fatalf(`Wrong encoding: '%s'`, encoded)
+	}
+}
+
+func TestWordLists(t *testing.T) {
+	if len(wordValues) != len(evenWords)+len(oddWords) {
+		t.Fatal(`Word lists contain duplicate words`)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for range testLoopCount {
+		b := make([]byte, rand.Intn(30)+1)
+		_, _ = cryptorand.Read(b)
+
+		encoded := Encode(b)
+		decoded, err := Decode(encoded)
+		if err != nil {
+			t.Fatalf(`Error decoding '%s': %v`, encoded, err)
+		}
+
+		if !bytes.Equal(b, decoded) {
+			t.Fatalf(`Decoding '%s' did not result in '%x', but '%x'`, encoded, b, decoded)
+		}
+	}
+}
+
+func TestDecodeSeparatorsAndCase(t *testing.T) {
+	decoded, err := Decode("  Topmost Istanbul\tPLUTO-vagabond ")
+	if err != nil {
+		t.Fatalf(`Error decoding: %v`, err)
+	}
+
+	if !bytes.Equal(decoded, []byte{0xe5, 0x82, 0x94, 0xf2}) {
+		t.Fatalf(`Wrong decoding: '%x'`, decoded)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	texts := map[string]string{
+		``:                        `No words`,
+		`topmost-istanbul-plutto`: `not a valid word`,
+		`istanbul-topmost`:        `wrong position`,
+		`topmost-pluto`:           `wrong position`,
+	}
+
+	for text, expectedError := range texts {
+		_, err := Decode(text)
+		if err == nil {
+			t.Fatalf(`No error decoding '%s'`, text)
+		}
+
+		if !strings.Contains(err.Error(), expectedError) {
+			t.Fatalf(`Wrong error decoding '%s': %v`, text, err)
+		}
+	}
+}